@@ -0,0 +1,180 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+)
+
+// This file holds the set operations that return constraints rather than
+// booleans. They work on the domain model and render the result back to
+// Composer syntax, so "^1.2" intersected with "<1.5 || ^2.0" gives
+// ">=1.2 <1.5".
+//
+// Every branch can only be matched through "!=" terms, so a result matching
+// every branch is written as what it leaves out: the complement of "^1.0" is
+// "!=1.*" and that of "dev-main" is "!=dev-main". The complement of "^1.2"
+// matches every branch and the numeric versions outside 1.2.0 to 2.0.0,
+// which no constraint can write down, and a bare "@beta" filters versions by
+// stability rather than bounding them; for such results the operations
+// return ErrInexpressible instead of a constraint matching other versions.
+
+// ErrInexpressible is returned by Intersect, Union, Difference and
+// Complement when no constraint matches exactly the versions of the result.
+var ErrInexpressible = errors.New("result cannot be expressed as a constraint")
+
+// Intersect returns the constraints matching the versions both a and b
+// match, such as the effective range of a package several dependents
+// require.
+func Intersect(a, b Constraints) (Constraints, error) {
+	left, right, err := operandDomains(a, b)
+	if err != nil {
+		return nil, err
+	}
+	result, err := constraintsFromDomains(intersectDomainUnions(left, right), func(v *Version) bool {
+		return a.Check(v) && b.Check(v)
+	}, a, b)
+	if err != nil {
+		return nil, fmt.Errorf("intersection of %s and %s: %w", a.Pretty(), b.Pretty(), err)
+	}
+	return result, nil
+}
+
+// Union returns the constraints matching the versions a or b match.
+func Union(a, b Constraints) (Constraints, error) {
+	left, right, err := operandDomains(a, b)
+	if err != nil {
+		return nil, err
+	}
+	result, err := constraintsFromDomains(append(left, right...), func(v *Version) bool {
+		return a.Check(v) || b.Check(v)
+	}, a, b)
+	if err != nil {
+		return nil, fmt.Errorf("union of %s and %s: %w", a.Pretty(), b.Pretty(), err)
+	}
+	return result, nil
+}
+
+// Difference returns the constraints matching the versions a matches and b
+// does not.
+func Difference(a, b Constraints) (Constraints, error) {
+	left, right, err := operandDomains(a, b)
+	if err != nil {
+		return nil, err
+	}
+	result, err := constraintsFromDomains(intersectDomainUnions(left, []constraintDomain{complementDomain(right)}), func(v *Version) bool {
+		return a.Check(v) && !b.Check(v)
+	}, a, b)
+	if err != nil {
+		return nil, fmt.Errorf("difference of %s and %s: %w", a.Pretty(), b.Pretty(), err)
+	}
+	return result, nil
+}
+
+// Complement returns the constraints matching the versions cs does not
+// match, turning a conflict declaration into the range it allows.
+func Complement(cs Constraints) (Constraints, error) {
+	domains, err := cs.domains()
+	if err != nil {
+		return nil, err
+	}
+	result, err := constraintsFromDomains([]constraintDomain{complementDomain(domains)}, func(v *Version) bool {
+		return !cs.Check(v)
+	}, cs)
+	if err != nil {
+		return nil, fmt.Errorf("complement of %s: %w", cs.Pretty(), err)
+	}
+	return result, nil
+}
+
+func operandDomains(a, b Constraints) ([]constraintDomain, []constraintDomain, error) {
+	left, err := a.domains()
+	if err != nil {
+		return nil, nil, err
+	}
+	right, err := b.domains()
+	if err != nil {
+		return nil, nil, err
+	}
+	return left, right, nil
+}
+
+func intersectDomainUnions(left, right []constraintDomain) []constraintDomain {
+	var domains []constraintDomain
+	for _, leftDomain := range left {
+		for _, rightDomain := range right {
+			intersection := intersectDomains(leftDomain, rightDomain)
+			if !domainEmpty(intersection) {
+				domains = append(domains, intersection)
+			}
+		}
+	}
+	return domains
+}
+
+// complementDomain returns the single domain holding every version the union
+// of domains does not: the gaps between its merged numeric intervals, and the
+// branches it leaves out.
+func complementDomain(domains []constraintDomain) constraintDomain {
+	complement := constraintDomain{
+		branches:      map[string]struct{}{},
+		branchExclude: map[string]struct{}{},
+	}
+
+	// lower is where the next gap starts; nil is the lowest version.
+	var lower *versionBound
+	unbounded := false
+	for _, interval := range mergeNumericIntervals(unionNumericIntervals(domains)) {
+		if interval.lower != nil {
+			gap := versionInterval{lower: lower, upper: flipBound(interval.lower)}
+			if intervalHasAnyVersion(gap) {
+				complement.numeric = append(complement.numeric, gap)
+			}
+		}
+		if interval.upper == nil {
+			unbounded = true
+			break
+		}
+		lower = flipBound(interval.upper)
+	}
+	if !unbounded {
+		complement.numeric = append(complement.numeric, versionInterval{lower: lower})
+	}
+
+	branches := snapshotBranches(domains)
+	for _, name := range branches.Names {
+		if branches.Exclude {
+			complement.branches[name] = struct{}{}
+		} else {
+			complement.branchExclude[name] = struct{}{}
+		}
+	}
+	complement.anyBranch = !branches.Exclude
+	return complement
+}
+
+// flipBound turns the bound on one side of a version into the bound on the
+// other side: ">=1.0" ends where "<1.0" starts.
+func flipBound(bound *versionBound) *versionBound {
+	return &versionBound{version: bound.version, inclusive: !bound.inclusive}
+}
+
+// constraintsFromDomains renders the union of domains as constraints. The
+// domain model does not describe stability filters and "-stable" bounds, so
+// the result must also agree with matches, the operation applied to Check,
+// on the versions around each term of the operands and the result.
+func constraintsFromDomains(domains []constraintDomain, matches func(*Version) bool, operands ...Constraints) (Constraints, error) {
+	rendered, ok := renderDomains(domains)
+	if !ok {
+		return nil, ErrInexpressible
+	}
+	result, err := NewConstraint(rendered)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range checkProbes(append(operands, result)...) {
+		if result.Check(v) != matches(v) {
+			return nil, ErrInexpressible
+		}
+	}
+	return result, nil
+}
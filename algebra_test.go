@@ -0,0 +1,148 @@
+package version
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConstraintSetOperations(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       func(a, b Constraints) (Constraints, error)
+		left     string
+		right    string
+		expected string
+	}{
+		{"intersect", Intersect, "^1.2", "<1.5 || ^2.0", ">=1.2,<1.5"},
+		{"intersect", Intersect, "^1.0", "^1.5, !=1.6.0", "^1.5,!=1.6"},
+		{"intersect", Intersect, "^1.0", "^2.0", "<0.0.0.0-dev"},
+		{"intersect", Intersect, "^1.0 || dev-main", "dev-main || dev-foo", "dev-main"},
+		{"intersect", Intersect, "!=dev-main", "!=dev-foo", "!=dev-foo,!=dev-main"},
+		{"intersect", Intersect, "*", "~1.2.3", "~1.2.3"},
+		{"intersect", Intersect, "^1.0@beta", "^1.0", ">=1.0-beta,<2.0"},
+		{"union", Union, "^1.0", "^1.5", "^1.0"},
+		{"union", Union, "^1.0", "^3.0", "^1.0||^3.0"},
+		{"union", Union, "<1.0", ">=1.0", ">=0.0"},
+		{"union", Union, "!=1.5", "1.5", "*"},
+		{"union", Union, "dev-main", "1.0.0", "1.0||dev-main"},
+		{"difference", Difference, "^1.0", "^1.5", ">=1.0,<1.5"},
+		{"difference", Difference, "^1.0", "1.5.0", "^1.0,!=1.5"},
+		{"difference", Difference, "^1.0 || dev-main", "^1.0", "dev-main"},
+		{"difference", Difference, "!=dev-foo", "!=dev-main", "dev-main"},
+		{"difference", Difference, "^1.0", "*", "<0.0.0.0-dev"},
+		// The numeric part of an inexpressible complement.
+		{"difference", Difference, ">=0.0.0.0-dev", "<1.5", ">=1.5"},
+		{"difference", Difference, ">=0.0.0.0-dev", ">=1.0", ">=0.0,<1.0"},
+		{"difference", Difference, ">=0.0.0.0-dev", "^1.2", ">=0.0,<1.2||>=2.0"},
+	}
+
+	for _, tc := range tests {
+		left := MustConstraints(NewConstraint(tc.left))
+		right := MustConstraints(NewConstraint(tc.right))
+		actual, err := tc.op(left, right)
+		if err != nil {
+			t.Errorf("%s(%q, %q): unexpected error: %s", tc.name, tc.left, tc.right, err)
+			continue
+		}
+		if actual.String() != tc.expected {
+			t.Errorf("%s(%q, %q): expected %q, got %q", tc.name, tc.left, tc.right, tc.expected, actual.String())
+		}
+	}
+}
+
+func TestComplement(t *testing.T) {
+	tests := []struct {
+		constraint string
+		expected   string
+	}{
+		{"^1.0 || ^3.0", "!=1.*,!=3.*"},
+		{"!=1.5.0", "1.5"},
+		{"1.5.0", "!=1.5"},
+		{"dev-main", "!=dev-main"},
+		{"!=dev-main, !=dev-foo", "dev-foo||dev-main"},
+		{"*", "<0.0.0.0-dev"},
+		{"<0.0.0.0-dev", "*"},
+	}
+
+	for _, tc := range tests {
+		actual, err := Complement(MustConstraints(NewConstraint(tc.constraint)))
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", tc.constraint, err)
+			continue
+		}
+		if actual.String() != tc.expected {
+			t.Errorf("%q: expected %q, got %q", tc.constraint, tc.expected, actual.String())
+		}
+	}
+}
+
+func TestSetOperationsInexpressible(t *testing.T) {
+	// The complement of every numeric range matches every branch but only
+	// some numeric versions, and only "!=" terms can match every branch. The
+	// last two depend on stability in a way the rendering does not reproduce.
+	for _, constraint := range []string{"<1.5", ">=1.0", ">=1.5", "^1.2", ">1.0 <=2.0", "@beta", "<2.0-stable"} {
+		_, err := Complement(MustConstraints(NewConstraint(constraint)))
+		if !errors.Is(err, ErrInexpressible) {
+			t.Errorf("Complement(%q): expected ErrInexpressible, got %v", constraint, err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		op    func(a, b Constraints) (Constraints, error)
+		left  string
+		right string
+	}{
+		{"intersect", Intersect, "@beta", "^1.0"},
+		{"union", Union, "@beta", "^1.0"},
+		{"difference", Difference, "*", "^1.2"},
+		{"difference", Difference, "^1.0", "@stable"},
+	}
+	for _, tc := range tests {
+		_, err := tc.op(MustConstraints(NewConstraint(tc.left)), MustConstraints(NewConstraint(tc.right)))
+		if !errors.Is(err, ErrInexpressible) {
+			t.Errorf("%s(%q, %q): expected ErrInexpressible, got %v", tc.name, tc.left, tc.right, err)
+		}
+	}
+}
+
+func TestConstraintSetOperationsCheck(t *testing.T) {
+	constraints := []string{"^1.2", "~1.4.5 || ^3.0", ">=1.0-beta1 <2.0, !=1.3.0", "1.5.*", "<1.0 || >2.0", "dev-main || ^2.0"}
+	versions := []string{
+		"0.9.0", "1.0.0-beta1", "1.0.0", "1.2.0-dev", "1.2.0", "1.3.0", "1.4.6", "1.5.2",
+		"2.0.0-RC1", "2.0.0", "2.4.0", "3.1.0", "dev-main",
+	}
+
+	for _, left := range constraints {
+		a := MustConstraints(NewConstraint(left))
+		for _, right := range constraints {
+			b := MustConstraints(NewConstraint(right))
+			intersection, err := Intersect(a, b)
+			if err != nil {
+				t.Fatalf("Intersect(%q, %q): %s", left, right, err)
+			}
+			union, err := Union(a, b)
+			if err != nil {
+				t.Fatalf("Union(%q, %q): %s", left, right, err)
+			}
+			difference, err := Difference(a, b)
+			if err != nil {
+				t.Fatalf("Difference(%q, %q): %s", left, right, err)
+			}
+
+			for _, raw := range versions {
+				v := Must(NewVersion(raw))
+				inA, inB := a.Check(v), b.Check(v)
+				if intersection.Check(v) != (inA && inB) {
+					t.Errorf("Intersect(%q, %q) = %q disagrees on %s", left, right, intersection, raw)
+				}
+				if union.Check(v) != (inA || inB) {
+					t.Errorf("Union(%q, %q) = %q disagrees on %s", left, right, union, raw)
+				}
+				if difference.Check(v) != (inA && !inB) {
+					t.Errorf("Difference(%q, %q) = %q disagrees on %s", left, right, difference, raw)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package version
+
+import (
+	"iter"
+	"slices"
+	"strconv"
+)
+
+// This file holds the read-only view of parsed constraints: the Operator
+// enum, accessors for the parts of a Constraint and iterators over
+// Constraints.
+
+// Operator is the comparison a single Constraint applies.
+type Operator int
+
+const (
+	OpEqual              Operator = iota // "=", "==" or a bare version
+	OpNotEqual                           // "!=" or "<>"
+	OpGreaterThan                        // ">"
+	OpGreaterThanOrEqual                 // ">="
+	OpLessThan                           // "<"
+	OpLessThanOrEqual                    // "<="
+	OpCaret                              // "^"
+	OpTilde                              // "~"
+	OpAny                                // "*", "x", "*@dev" or a bare "@stability"
+)
+
+var operatorsByString = map[string]Operator{
+	"":   OpEqual,
+	"=":  OpEqual,
+	"==": OpEqual,
+	"!=": OpNotEqual,
+	"<>": OpNotEqual,
+	">":  OpGreaterThan,
+	">=": OpGreaterThanOrEqual,
+	"<":  OpLessThan,
+	"<=": OpLessThanOrEqual,
+	"^":  OpCaret,
+	"~":  OpTilde,
+	"*":  OpAny,
+}
+
+// String returns the canonical spelling of the operator.
+func (o Operator) String() string {
+	switch o {
+	case OpEqual:
+		return "="
+	case OpNotEqual:
+		return "!="
+	case OpGreaterThan:
+		return ">"
+	case OpGreaterThanOrEqual:
+		return ">="
+	case OpLessThan:
+		return "<"
+	case OpLessThanOrEqual:
+		return "<="
+	case OpCaret:
+		return "^"
+	case OpTilde:
+		return "~"
+	case OpAny:
+		return "*"
+	default:
+		return "Operator(" + strconv.Itoa(int(o)) + ")"
+	}
+}
+
+// Operator returns the comparison c applies. Hyphen ranges are split into
+// an OpGreaterThanOrEqual and an OpLessThan or OpLessThanOrEqual term when
+// they are parsed. Every match-all spelling, such as "x", "*.*", "*@dev" or
+// "x-dev", reports OpAny.
+func (c *Constraint) Operator() Operator {
+	if c.matchAll {
+		return OpAny
+	}
+	return operatorsByString[c.operator]
+}
+
+// Version returns a copy of the version c compares against, or nil for
+// OpAny. The copy carries any implicit suffix parsing added, so ">=1.0@beta"
+// reports 1.0-beta. For a wildcard such as 1.2.* it is the version the
+// wildcard starts at, 1.2.0.
+func (c *Constraint) Version() *Version {
+	if c.check == nil || c.matchAll {
+		return nil
+	}
+	return c.check.clone()
+}
+
+// Stability returns the @stability flag of c in canonical form ("dev",
+// "alpha", "beta", "RC" or "stable"), or "" if it has none.
+func (c *Constraint) Stability() string {
+	if c.stability != "" {
+		return expandStability(c.stability)
+	}
+	return c.flag
+}
+
+// Precision returns the number of version segments written in c: 2 for
+// "^1.2", 3 for "~1.2.3". Caret and tilde ranges widen with fewer segments.
+// A wildcard such as 1.2.* counts its wildcard segment.
+func (c *Constraint) Precision() int {
+	return c.origSegments
+}
+
+// Wildcard reports whether c matches every version sharing the segments of
+// Version() up to its wildcard, as 1.2.* and 1.2.x do.
+func (c *Constraint) Wildcard() bool {
+	return c.wildcard
+}
+
+// Groups returns an iterator over the OR groups of cs. Each group is a copy
+// of the AND terms a version must all satisfy.
+func (cs Constraints) Groups() iter.Seq[[]*Constraint] {
+	return func(yield func([]*Constraint) bool) {
+		for _, group := range cs {
+			if !yield(slices.Clone(group)) {
+				return
+			}
+		}
+	}
+}
+
+// Terms returns an iterator over every single constraint in cs, paired with
+// the index of the OR group it belongs to.
+func (cs Constraints) Terms() iter.Seq2[int, *Constraint] {
+	return func(yield func(int, *Constraint) bool) {
+		for i, group := range cs {
+			for _, c := range group {
+				if !yield(i, c) {
+					return
+				}
+			}
+		}
+	}
+}
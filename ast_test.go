@@ -0,0 +1,120 @@
+package version
+
+import (
+	"testing"
+)
+
+func TestConstraintAccessors(t *testing.T) {
+	tests := []struct {
+		constraint string
+		operator   Operator
+		version    string
+		stability  string
+		precision  int
+		wildcard   bool
+	}{
+		{"1.2.3", OpEqual, "1.2.3.0", "", 3, false},
+		{"==1.2", OpEqual, "1.2.0.0", "", 2, false},
+		{"<>1.2", OpNotEqual, "1.2.0.0", "", 2, false},
+		{">1.0", OpGreaterThan, "1.0.0.0", "", 2, false},
+		{">=1.0@beta", OpGreaterThanOrEqual, "1.0.0.0-beta", "beta", 2, false},
+		{"<2.0-dev", OpLessThan, "2.0.0.0-dev", "", 2, false},
+		{"<=2", OpLessThanOrEqual, "2.0.0.0", "", 1, false},
+		{"^1.2@RC", OpCaret, "1.2.0.0-RC", "RC", 2, false},
+		{"~1.2.3@stable", OpTilde, "1.2.3.0", "stable", 3, false},
+		{"1.2.*", OpEqual, "1.2.0.0", "", 3, true},
+		{">=2.x", OpGreaterThanOrEqual, "2.0.0.0", "", 2, true},
+		{"dev-main", OpEqual, "dev-main", "", 1, false},
+		{"*", OpAny, "", "", 1, false},
+		{"@dev", OpAny, "", "dev", 1, false},
+		{"x", OpAny, "", "", 1, false},
+		{"*.*", OpAny, "", "", 1, false},
+		{"*@dev", OpAny, "", "dev", 1, false},
+		{"x@beta", OpAny, "", "beta", 1, false},
+		{"x-dev", OpAny, "", "dev", 1, false},
+	}
+
+	for _, tc := range tests {
+		cs := MustConstraints(NewConstraint(tc.constraint))
+		c := cs[0][0]
+		if c.Operator() != tc.operator {
+			t.Errorf("%q: expected operator %s, got %s", tc.constraint, tc.operator, c.Operator())
+		}
+		if v := c.Version(); (v == nil && tc.version != "") || (v != nil && v.NormalizedString() != tc.version) {
+			t.Errorf("%q: expected version %q, got %v", tc.constraint, tc.version, v)
+		}
+		if c.Stability() != tc.stability {
+			t.Errorf("%q: expected stability %q, got %q", tc.constraint, tc.stability, c.Stability())
+		}
+		if c.Precision() != tc.precision {
+			t.Errorf("%q: expected precision %d, got %d", tc.constraint, tc.precision, c.Precision())
+		}
+		if c.Wildcard() != tc.wildcard {
+			t.Errorf("%q: expected wildcard %t, got %t", tc.constraint, tc.wildcard, c.Wildcard())
+		}
+	}
+}
+
+func TestConstraintVersionIsACopy(t *testing.T) {
+	c := MustConstraints(NewConstraint("^1.2"))[0][0]
+	c.Version().IncreaseMajor()
+	if !c.Check(Must(NewVersion("1.5.0"))) {
+		t.Error("mutating Version() changed the constraint")
+	}
+}
+
+func TestOperatorString(t *testing.T) {
+	for s, op := range operatorsByString {
+		if s != "" && s != "==" && s != "<>" && op.String() != s {
+			t.Errorf("expected %s, got %s", s, op)
+		}
+	}
+	if Operator(42).String() != "Operator(42)" {
+		t.Errorf("unexpected String for unknown operator: %s", Operator(42))
+	}
+}
+
+func TestConstraintsIterators(t *testing.T) {
+	cs := MustConstraints(NewConstraint(">=1.0 <2.0 || 1.0 - 1.5 || ^3.0"))
+
+	var groups [][]string
+	for group := range cs.Groups() {
+		var terms []string
+		for _, c := range group {
+			terms = append(terms, c.Operator().String()+c.Version().NormalizedString())
+		}
+		groups = append(groups, terms)
+	}
+	expected := [][]string{
+		{">=1.0.0.0", "<2.0.0.0"},
+		{">=1.0.0.0", "<1.6.0.0"},
+		{"^3.0.0.0"},
+	}
+	if len(groups) != len(expected) {
+		t.Fatalf("expected %d groups, got %v", len(expected), groups)
+	}
+	for i := range expected {
+		if len(groups[i]) != len(expected[i]) {
+			t.Fatalf("group %d: expected %v, got %v", i, expected[i], groups[i])
+		}
+		for j := range expected[i] {
+			if groups[i][j] != expected[i][j] {
+				t.Errorf("group %d term %d: expected %s, got %s", i, j, expected[i][j], groups[i][j])
+			}
+		}
+	}
+
+	var indexes []int
+	for i, c := range cs.Terms() {
+		if c == nil {
+			t.Fatal("Terms yielded a nil constraint")
+		}
+		indexes = append(indexes, i)
+		if len(indexes) == 4 {
+			break
+		}
+	}
+	if len(indexes) != 4 || indexes[0] != 0 || indexes[1] != 0 || indexes[2] != 1 || indexes[3] != 1 {
+		t.Errorf("unexpected group indexes %v", indexes)
+	}
+}
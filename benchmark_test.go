@@ -102,6 +102,23 @@ func BenchmarkConstraintCheck(b *testing.B) {
 	}
 }
 
+func BenchmarkMatcherCheck(b *testing.B) {
+	for _, c := range benchConstraints {
+		constraint, err := NewConstraint(c)
+		if err != nil {
+			b.Fatal(err)
+		}
+		m := Compile(constraint)
+		v := Must(NewVersion("1.2.3"))
+		b.Run(c, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = m.Check(v)
+			}
+		})
+	}
+}
+
 // BenchmarkSatisfies measures the full parse-and-check path that most callers
 // actually use, including version and constraint parsing on every call.
 func BenchmarkSatisfies(b *testing.B) {
@@ -0,0 +1,121 @@
+package version
+
+// This file holds the public lower/upper bound API, the counterpart of
+// Composer's getLowerBound() and getUpperBound().
+
+// Bound is one end of the range of versions a constraint can match. An
+// infinite bound has no Version; a lower bound is never infinite, since the
+// lowest version is 0.0.0.0-dev.
+type Bound struct {
+	Version   *Version `json:"version,omitempty"`
+	Inclusive bool     `json:"inclusive"`
+	Infinite  bool     `json:"infinite,omitempty"`
+}
+
+// Bounds returns the lowest and highest versions cs can match, across all
+// of its OR groups. ^1.2 reports >= 1.2.0.0-dev and < 2.0.0.0-dev. Excluded
+// points such as != 1.5 do not narrow the bounds, and constraints that only
+// match branches (dev-*) report the full range, as in Composer. Constraints
+// that match nothing, such as ">2.0 <1.0", report the empty range of
+// Composer's MatchNoneConstraint: both bounds exclusive at 0.0.0.0-dev.
+func (cs Constraints) Bounds() (lower, upper Bound, err error) {
+	domains, err := cs.domains()
+	if err != nil {
+		return Bound{}, Bound{}, err
+	}
+	lower, upper = domainUnionBounds(domains)
+	return lower, upper, nil
+}
+
+// Bounds returns the lowest and highest versions c can match, like
+// Constraints.Bounds.
+func (c *Constraint) Bounds() (lower, upper Bound, err error) {
+	domain, err := singleConstraintDomain(c)
+	if err != nil {
+		return Bound{}, Bound{}, err
+	}
+	lower, upper = domainUnionBounds([]constraintDomain{domain})
+	return lower, upper, nil
+}
+
+func domainUnionBounds(domains []constraintDomain) (Bound, Bound) {
+	lower, upper, ok := unionBounds(domains)
+	if !ok {
+		if domainUnionMatchesBranch(domains) {
+			return zeroBound(), infiniteBound()
+		}
+		return noneBound(), noneBound()
+	}
+
+	return publicLowerBound(lower), publicUpperBound(upper)
+}
+
+// domainUnionMatchesBranch reports whether any of domains can match a
+// branch (dev-*).
+func domainUnionMatchesBranch(domains []constraintDomain) bool {
+	for _, domain := range domains {
+		if domain.anyBranch || len(domain.branches) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func publicLowerBound(bound *versionBound) Bound {
+	if bound == nil {
+		return zeroBound()
+	}
+	return Bound{Version: publicBoundVersion(bound.version), Inclusive: bound.inclusive}
+}
+
+func publicUpperBound(bound *versionBound) Bound {
+	if bound == nil {
+		return infiniteBound()
+	}
+	return Bound{Version: publicBoundVersion(bound.version), Inclusive: bound.inclusive}
+}
+
+// unionBounds folds the numeric intervals of domains into their outermost
+// bounds; a nil bound is unbounded. ok is false when no interval holds a
+// version.
+func unionBounds(domains []constraintDomain) (lower, upper *versionBound, ok bool) {
+	var intervals []versionInterval
+	for _, interval := range unionNumericIntervals(domains) {
+		if intervalHasAnyVersion(interval) {
+			intervals = append(intervals, interval)
+		}
+	}
+	if len(intervals) == 0 {
+		return nil, nil, false
+	}
+
+	lower, upper = intervals[0].lower, intervals[0].upper
+	for _, interval := range intervals[1:] {
+		lower = minLowerBound(lower, interval.lower)
+		upper = maxUpper(upper, interval.upper)
+	}
+	return lower, upper, true
+}
+
+// publicBoundVersion reparses a bound's version from its normalized form, so
+// that Original() matches what the bound stands for rather than the
+// constraint text it was derived from.
+func publicBoundVersion(v *Version) *Version {
+	if parsed, err := NewVersion(v.NormalizedString()); err == nil {
+		return parsed
+	}
+	return v.clone()
+}
+
+func zeroBound() Bound {
+	return Bound{Version: Must(NewVersion("0.0.0.0-dev")), Inclusive: true}
+}
+
+// noneBound is either end of the empty range.
+func noneBound() Bound {
+	return Bound{Version: Must(NewVersion("0.0.0.0-dev"))}
+}
+
+func infiniteBound() Bound {
+	return Bound{Infinite: true}
+}
@@ -0,0 +1,69 @@
+package version
+
+import "testing"
+
+func TestConstraintsBounds(t *testing.T) {
+	tests := []struct {
+		constraint     string
+		lower          string
+		lowerInclusive bool
+		upper          string // empty for +Inf
+		upperInclusive bool
+	}{
+		{"^1.2", "1.2.0.0-dev", true, "2.0.0.0-dev", false},
+		{"~1.2.3", "1.2.3.0-dev", true, "1.3.0.0-dev", false},
+		{"^0.3", "0.3.0.0-dev", true, "0.4.0.0-dev", false},
+		{">1.0", "1.0.0.0", false, "", false},
+		{"<=2.0", "0.0.0.0-dev", true, "2.0.0.0", true},
+		{"1.2.*", "1.2.0.0-dev", true, "1.3.0.0-dev", false},
+		{"1.0 - 2.0", "1.0.0.0-dev", true, "2.1.0.0-dev", false},
+		{"^1.2 || ^3.0", "1.2.0.0-dev", true, "4.0.0.0-dev", false},
+		{">=1.0 <1.5 || >=2.0", "1.0.0.0-dev", true, "", false},
+		{"^1.2, !=1.5.0", "1.2.0.0-dev", true, "2.0.0.0-dev", false},
+		{"1.2.3", "1.2.3.0", true, "1.2.3.0", true},
+		{"dev-main", "0.0.0.0-dev", true, "", false},
+		{"*", "0.0.0.0-dev", true, "", false},
+		{">2.0 <1.0", "0.0.0.0-dev", false, "0.0.0.0-dev", false},
+		{"<0.0.0.0-dev", "0.0.0.0-dev", false, "0.0.0.0-dev", false},
+		{">2.0 <1.0 || ^3.0", "3.0.0.0-dev", true, "4.0.0.0-dev", false},
+		{">2.0 <1.0 || dev-main", "0.0.0.0-dev", true, "", false},
+	}
+
+	for _, tc := range tests {
+		lower, upper, err := MustConstraints(NewConstraint(tc.constraint)).Bounds()
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.constraint, err)
+			continue
+		}
+		if lower.Infinite || lower.Version.Original() != tc.lower || lower.Inclusive != tc.lowerInclusive {
+			t.Errorf("%q: expected lower %s (inclusive %t), got %+v", tc.constraint, tc.lower, tc.lowerInclusive, lower)
+		}
+		if tc.upper == "" {
+			if !upper.Infinite || upper.Version != nil {
+				t.Errorf("%q: expected infinite upper bound, got %+v", tc.constraint, upper)
+			}
+			continue
+		}
+		if upper.Infinite || upper.Version.Original() != tc.upper || upper.Inclusive != tc.upperInclusive {
+			t.Errorf("%q: expected upper %s (inclusive %t), got %+v", tc.constraint, tc.upper, tc.upperInclusive, upper)
+		}
+	}
+}
+
+func TestConstraintBounds(t *testing.T) {
+	cs := MustConstraints(NewConstraint(">=1.0 <2.0"))
+	lower, upper, err := cs[0][0].Bounds()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lower.Version.Original() != "1.0.0.0-dev" || !upper.Infinite {
+		t.Errorf("unexpected bounds for >=1.0: %+v, %+v", lower, upper)
+	}
+	lower, upper, err = cs[0][1].Bounds()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lower.Version.Original() != "0.0.0.0-dev" || upper.Version.Original() != "2.0.0.0-dev" || upper.Inclusive {
+		t.Errorf("unexpected bounds for <2.0: %+v, %+v", lower, upper)
+	}
+}
@@ -0,0 +1,305 @@
+package version
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// This file holds the non-mutating release-progression API: Bump,
+// NextPrerelease, Promote and StartPrerelease. Unlike the Increase* methods
+// they return a new Version and leave the receiver untouched.
+
+// BumpLevel selects the numeric segment a bump operates on.
+type BumpLevel int
+
+const (
+	BumpMajor BumpLevel = iota
+	BumpMinor
+	BumpPatch
+	BumpBuild
+)
+
+// promotionOrder lists the prerelease stabilities in the order Promote walks
+// them, matching the ranks used by comparePrereleases.
+var promotionOrder = []string{StabilityDev, StabilityAlpha, StabilityBeta, StabilityRC, StabilityStable}
+
+func (l BumpLevel) String() string {
+	switch l {
+	case BumpMajor:
+		return "major"
+	case BumpMinor:
+		return "minor"
+	case BumpPatch:
+		return "patch"
+	case BumpBuild:
+		return "build"
+	default:
+		return "BumpLevel(" + strconv.Itoa(int(l)) + ")"
+	}
+}
+
+// Bump returns a new version with the segment at level incremented and all
+// lower segments reset to zero. The prerelease is dropped.
+//
+// A prerelease of the release the bump targets is released rather than
+// skipped: BumpMajor turns 2.0.0-beta1 into 2.0.0 and BumpMinor turns
+// 1.3.0-RC2 into 1.3.0. Branch versions cannot be bumped.
+func (v *Version) Bump(level BumpLevel) (*Version, error) {
+	segments, err := v.bumpSegments(level)
+	if err != nil {
+		return nil, err
+	}
+	return v.derive(segments, ""), nil
+}
+
+// NextPrerelease returns a new version with the prerelease number
+// incremented: beta1 becomes beta2 and a bare beta becomes beta1. The
+// version must be a numbered alpha, beta, RC or patch prerelease; dev
+// snapshots are not numbered.
+//
+// SemVer2 versions follow spec precedence instead: a numeric last identifier
+// is incremented, so beta.9 becomes beta.10, and any other gets ".1"
+// appended, so beta9 becomes beta9.1 rather than the lower beta10.
+func (v *Version) NextPrerelease() (*Version, error) {
+	if v.scheme == SemVer2 {
+		return v.nextSemverPrerelease()
+	}
+	stability, number, err := v.prereleaseStep()
+	if err != nil {
+		return nil, err
+	}
+	if stability == StabilityDev {
+		return nil, fmt.Errorf("cannot number dev prerelease: %s", v.original)
+	}
+	return v.derive(v.Segments64(), stability+strconv.FormatInt(number+1, 10)), nil
+}
+
+// Promote returns a new version moved to the next stability: dev becomes
+// alpha1, alpha becomes beta1, beta becomes RC1 and RC becomes the stable
+// release. Stable versions and patch releases cannot be promoted.
+//
+// For SemVer2 versions the stability is read from the first identifier and
+// the next one is written as alpha.1, beta.1 or rc.1, which must sort above
+// the input by spec precedence: 1.0.0-beta becomes 1.0.0-rc.1, while
+// 1.0.0-dev cannot be promoted because alpha sorts before dev.
+func (v *Version) Promote() (*Version, error) {
+	stability, err := v.promotionStability()
+	if err != nil {
+		return nil, err
+	}
+	if stability == "patch" {
+		return nil, fmt.Errorf("cannot promote patch release: %s", v.original)
+	}
+
+	for i, candidate := range promotionOrder {
+		if candidate != stability {
+			continue
+		}
+		next := promotionOrder[i+1]
+		if next == StabilityStable {
+			return v.derive(v.Segments64(), ""), nil
+		}
+		promoted := v.derive(v.Segments64(), v.prereleaseLabel(next))
+		if promoted.Compare(v) <= 0 {
+			return nil, fmt.Errorf("promoting %s to %s would not produce a newer version", v.original, next)
+		}
+		return promoted, nil
+	}
+	return nil, fmt.Errorf("cannot promote version: %s", v.original)
+}
+
+// StartPrerelease bumps the version at level and starts the given stability
+// (dev, alpha, beta or RC) on it: 1.2.3 with BumpMinor and beta yields
+// 1.3.0-beta1, or 1.3.0-beta.1 for SemVer2. Alpha, beta and RC start at 1;
+// dev is unnumbered. The result must be newer than the receiver, so
+// 2.0.0-RC1 cannot start a major beta.
+func (v *Version) StartPrerelease(level BumpLevel, stability string) (*Version, error) {
+	normalized := expandStability(stability)
+	if normalized == "stable" || !isPromotionStability(normalized) {
+		return nil, fmt.Errorf("unknown prerelease stability: %s", stability)
+	}
+
+	segments, err := v.bumpSegments(level)
+	if err != nil {
+		return nil, err
+	}
+
+	result := v.derive(segments, v.prereleaseLabel(normalized))
+	if result.Compare(v) <= 0 {
+		return nil, fmt.Errorf("starting %s %s on %s would not produce a newer version", level, normalized, v.original)
+	}
+	return result, nil
+}
+
+func isPromotionStability(stability string) bool {
+	for _, candidate := range promotionOrder {
+		if candidate == stability {
+			return true
+		}
+	}
+	return false
+}
+
+// prereleaseLabel returns the prerelease that starts stability: numbered 1
+// unless it is dev, and spelled like beta1 or, for SemVer2, beta.1.
+func (v *Version) prereleaseLabel(stability string) string {
+	switch {
+	case stability == StabilityDev:
+		return stability
+	case v.scheme == SemVer2:
+		return strings.ToLower(stability) + ".1"
+	default:
+		return stability + "1"
+	}
+}
+
+func (v *Version) bumpSegments(level BumpLevel) ([]int64, error) {
+	if v.branch != "" {
+		return nil, fmt.Errorf("cannot bump branch version: %s", v.original)
+	}
+	if level < BumpMajor || level > BumpBuild {
+		return nil, fmt.Errorf("unknown bump level: %s", level)
+	}
+
+	// SemVer2 versions have exactly three segments.
+	size := 4
+	if v.scheme == SemVer2 {
+		size = 3
+	}
+	if int(level) >= size {
+		return nil, fmt.Errorf("cannot bump %s segment of %s", level, v.original)
+	}
+	segments := v.Segments64()
+	for len(segments) < size {
+		segments = append(segments, 0)
+	}
+
+	// Under SemVer2 every prerelease precedes its release; under Composer a
+	// patch release follows it.
+	index := int(level)
+	releasesPrerelease := v.scheme == SemVer2 || parsePrereleasePart(v.pre).rank != prereleaseRankPatch
+	if v.IsPrerelease() && releasesPrerelease && allZero(segments[index+1:]) {
+		// v is a prerelease of exactly the release this bump targets.
+		return segments, nil
+	}
+
+	segments[index]++
+	for i := index + 1; i < len(segments); i++ {
+		segments[i] = 0
+	}
+	return segments, nil
+}
+
+// promotionStability returns the stability Promote moves v from, one of
+// promotionOrder or "patch".
+func (v *Version) promotionStability() (string, error) {
+	if v.scheme != SemVer2 {
+		stability, _, err := v.prereleaseStep()
+		return stability, err
+	}
+	if !v.IsPrerelease() {
+		return "", fmt.Errorf("not a prerelease: %s", v.original)
+	}
+
+	first, _, _ := strings.Cut(v.pre, ".")
+	switch parsePrereleasePart(first).rank {
+	case prereleaseRankDev:
+		return StabilityDev, nil
+	case prereleaseRankAlpha:
+		return StabilityAlpha, nil
+	case prereleaseRankBeta:
+		return StabilityBeta, nil
+	case prereleaseRankRC:
+		return StabilityRC, nil
+	default:
+		return "", fmt.Errorf("unsupported prerelease %q in %s", v.pre, v.original)
+	}
+}
+
+// nextSemverPrerelease implements NextPrerelease for SemVer2 versions.
+func (v *Version) nextSemverPrerelease() (*Version, error) {
+	if !v.IsPrerelease() {
+		return nil, fmt.Errorf("not a prerelease: %s", v.original)
+	}
+
+	identifiers := strings.Split(v.pre, ".")
+	last := identifiers[len(identifiers)-1]
+	if !isNumericIdentifier(last) {
+		return v.derive(v.Segments64(), v.pre+".1"), nil
+	}
+	number, err := strconv.ParseInt(last, 10, 64)
+	if err != nil || number == math.MaxInt64 {
+		return nil, fmt.Errorf("cannot increment prerelease %q in %s", v.pre, v.original)
+	}
+	identifiers[len(identifiers)-1] = strconv.FormatInt(number+1, 10)
+	return v.derive(v.Segments64(), strings.Join(identifiers, ".")), nil
+}
+
+// prereleaseStep splits a single-part prerelease such as "beta2" into its
+// canonical stability and number. A bare stability reports number 0.
+func (v *Version) prereleaseStep() (string, int64, error) {
+	if v.branch != "" {
+		return "", 0, fmt.Errorf("cannot advance branch version: %s", v.original)
+	}
+	if !v.IsPrerelease() {
+		return "", 0, fmt.Errorf("not a prerelease: %s", v.original)
+	}
+
+	part := parsePrereleasePart(v.pre)
+	if strings.Contains(v.pre, ".") || (part.hasSuffix && !part.suffixNum) {
+		return "", 0, fmt.Errorf("unsupported prerelease %q in %s", v.pre, v.original)
+	}
+
+	switch part.rank {
+	case prereleaseRankDev:
+		return StabilityDev, part.suffixValue, nil
+	case prereleaseRankAlpha:
+		return StabilityAlpha, part.suffixValue, nil
+	case prereleaseRankBeta:
+		return StabilityBeta, part.suffixValue, nil
+	case prereleaseRankRC:
+		return StabilityRC, part.suffixValue, nil
+	case prereleaseRankPatch:
+		return "patch", part.suffixValue, nil
+	default:
+		return "", 0, fmt.Errorf("unsupported prerelease %q in %s", v.pre, v.original)
+	}
+}
+
+// derive builds a new version from v with the given segments and prerelease.
+// Its original string keeps the spelling of v: the "v" prefix and the number
+// of segments the user wrote, extended to cover any non-zero segment.
+func (v *Version) derive(segments []int64, pre string) *Version {
+	written := countVersionSegments(v.original)
+	if written > len(segments) {
+		written = len(segments)
+	}
+	for i := len(segments) - 1; i >= written; i-- {
+		if segments[i] != 0 {
+			written = i + 1
+			break
+		}
+	}
+
+	parts := make([]string, written)
+	for i := range parts {
+		parts[i] = strconv.FormatInt(segments[i], 10)
+	}
+	original := strings.Join(parts, ".")
+	if trimmed := strings.TrimSpace(v.original); len(trimmed) > 0 && (trimmed[0] == 'v' || trimmed[0] == 'V') {
+		original = trimmed[:1] + original
+	}
+	if pre != "" {
+		original += "-" + pre
+	}
+
+	return &Version{
+		pre:      pre,
+		segments: segments,
+		si:       len(segments),
+		original: original,
+		scheme:   v.scheme,
+	}
+}
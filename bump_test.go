@@ -0,0 +1,208 @@
+package version
+
+import "testing"
+
+func TestBump(t *testing.T) {
+	tests := []struct {
+		input    string
+		level    BumpLevel
+		expected string
+	}{
+		{"1.2.3", BumpMajor, "2.0.0"},
+		{"1.2.3", BumpMinor, "1.3.0"},
+		{"1.2.3", BumpPatch, "1.2.4"},
+		{"1.2.3.4", BumpBuild, "1.2.3.5"},
+		{"v1.2", BumpMinor, "v1.3"},
+		{"v1.2", BumpPatch, "v1.2.1"},
+		{"1.2.3-beta1", BumpMajor, "2.0.0"},
+		{"2.0.0-beta1", BumpMajor, "2.0.0"},
+		{"1.3.0-RC2", BumpMinor, "1.3.0"},
+		{"1.3.0-RC2", BumpPatch, "1.3.0"},
+		{"1.0.0-patch1", BumpPatch, "1.0.1"},
+	}
+
+	for _, tc := range tests {
+		v := Must(NewVersion(tc.input))
+		before := v.NormalizedString()
+		bumped, err := v.Bump(tc.level)
+		if err != nil {
+			t.Errorf("Bump(%s, %s) unexpected error: %v", tc.input, tc.level, err)
+			continue
+		}
+		if bumped.Original() != tc.expected {
+			t.Errorf("Bump(%s, %s): expected %s, got %s", tc.input, tc.level, tc.expected, bumped.Original())
+		}
+		if !bumped.GreaterThan(v) {
+			t.Errorf("Bump(%s, %s): %s is not greater than the input", tc.input, tc.level, bumped.Original())
+		}
+		if v.NormalizedString() != before || v.Original() != tc.input {
+			t.Errorf("Bump(%s, %s) mutated the receiver to %s", tc.input, tc.level, v.Original())
+		}
+		if reparsed := Must(NewVersion(bumped.Original())); !reparsed.Equal(bumped) {
+			t.Errorf("Bump(%s, %s): original %s does not re-parse to the bumped version", tc.input, tc.level, bumped.Original())
+		}
+	}
+}
+
+func TestBumpErrors(t *testing.T) {
+	if _, err := Must(NewVersion("dev-main")).Bump(BumpMinor); err == nil {
+		t.Error("expected error bumping a branch version")
+	}
+	if _, err := Must(NewVersion("1.0.0")).Bump(BumpLevel(9)); err == nil {
+		t.Error("expected error for unknown bump level")
+	}
+}
+
+func TestNextPrerelease(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"1.0.0-beta1", "1.0.0-beta2"},
+		{"1.0.0-beta", "1.0.0-beta1"},
+		{"1.0.0-RC9", "1.0.0-RC10"},
+		{"v2.0-alpha3", "v2.0-alpha4"},
+		{"1.0.0-patch1", "1.0.0-patch2"},
+	}
+
+	for _, tc := range tests {
+		v := Must(NewVersion(tc.input))
+		next, err := v.NextPrerelease()
+		if err != nil {
+			t.Errorf("NextPrerelease(%s) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if next.Original() != tc.expected {
+			t.Errorf("NextPrerelease(%s): expected %s, got %s", tc.input, tc.expected, next.Original())
+		}
+		if !next.GreaterThan(v) {
+			t.Errorf("NextPrerelease(%s): %s is not greater than the input", tc.input, next.Original())
+		}
+	}
+
+	for _, input := range []string{"1.0.0", "1.0.0-dev", "dev-main", "1.0.0-beta1.x"} {
+		if _, err := Must(NewVersion(input)).NextPrerelease(); err == nil {
+			t.Errorf("NextPrerelease(%s): expected error", input)
+		}
+	}
+}
+
+func TestPromote(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"1.0.0-dev", "1.0.0-alpha1"},
+		{"1.0.0-alpha2", "1.0.0-beta1"},
+		{"1.0.0-beta3", "1.0.0-RC1"},
+		{"1.0.0-RC2", "1.0.0"},
+		{"1.0.0-rc2", "1.0.0"},
+	}
+
+	for _, tc := range tests {
+		v := Must(NewVersion(tc.input))
+		promoted, err := v.Promote()
+		if err != nil {
+			t.Errorf("Promote(%s) unexpected error: %v", tc.input, err)
+			continue
+		}
+		if promoted.Original() != tc.expected {
+			t.Errorf("Promote(%s): expected %s, got %s", tc.input, tc.expected, promoted.Original())
+		}
+		if !promoted.GreaterThan(v) {
+			t.Errorf("Promote(%s): %s is not greater than the input", tc.input, promoted.Original())
+		}
+	}
+
+	for _, input := range []string{"1.0.0", "1.0.0-patch1", "dev-main"} {
+		if _, err := Must(NewVersion(input)).Promote(); err == nil {
+			t.Errorf("Promote(%s): expected error", input)
+		}
+	}
+}
+
+func TestStartPrerelease(t *testing.T) {
+	tests := []struct {
+		input     string
+		level     BumpLevel
+		stability string
+		expected  string
+	}{
+		{"1.2.3", BumpMinor, StabilityBeta, "1.3.0-beta1"},
+		{"1.2.3", BumpMajor, StabilityRC, "2.0.0-RC1"},
+		{"1.2.3", BumpPatch, "alpha", "1.2.4-alpha1"},
+		{"1.2.3", BumpMinor, StabilityDev, "1.3.0-dev"},
+		{"2.0.0-beta2", BumpMajor, "rc", "2.0.0-RC1"},
+	}
+
+	for _, tc := range tests {
+		v := Must(NewVersion(tc.input))
+		started, err := v.StartPrerelease(tc.level, tc.stability)
+		if err != nil {
+			t.Errorf("StartPrerelease(%s, %s, %s) unexpected error: %v", tc.input, tc.level, tc.stability, err)
+			continue
+		}
+		if started.Original() != tc.expected {
+			t.Errorf("StartPrerelease(%s, %s, %s): expected %s, got %s", tc.input, tc.level, tc.stability, tc.expected, started.Original())
+		}
+	}
+
+	errorCases := []struct {
+		input     string
+		stability string
+	}{
+		{"1.2.3", StabilityStable},
+		{"1.2.3", "gamma"},
+		{"2.0.0-RC1", StabilityBeta},
+	}
+	for _, tc := range errorCases {
+		if _, err := Must(NewVersion(tc.input)).StartPrerelease(BumpMajor, tc.stability); err == nil {
+			t.Errorf("StartPrerelease(%s, major, %s): expected error", tc.input, tc.stability)
+		}
+	}
+}
+
+func TestSemverReleaseProgression(t *testing.T) {
+	tests := []struct {
+		input    string
+		step     func(*Version) (*Version, error)
+		name     string
+		expected string
+	}{
+		{"1.2.3", func(v *Version) (*Version, error) { return v.Bump(BumpPatch) }, "Bump(patch)", "1.2.4"},
+		{"1.2.3", func(v *Version) (*Version, error) { return v.Bump(BumpMajor) }, "Bump(major)", "2.0.0"},
+		{"1.3.0-rc.1", func(v *Version) (*Version, error) { return v.Bump(BumpMinor) }, "Bump(minor)", "1.3.0"},
+		{"1.0.0-beta.9", (*Version).NextPrerelease, "NextPrerelease", "1.0.0-beta.10"},
+		{"1.0.0-beta9", (*Version).NextPrerelease, "NextPrerelease", "1.0.0-beta9.1"},
+		{"1.0.0-beta", (*Version).NextPrerelease, "NextPrerelease", "1.0.0-beta.1"},
+		{"1.0.0-beta", (*Version).Promote, "Promote", "1.0.0-rc.1"},
+		{"1.0.0-alpha.3", (*Version).Promote, "Promote", "1.0.0-beta.1"},
+		{"1.0.0-RC.2", (*Version).Promote, "Promote", "1.0.0"},
+		{"1.2.3", func(v *Version) (*Version, error) { return v.StartPrerelease(BumpMinor, StabilityRC) }, "StartPrerelease(minor, RC)", "1.3.0-rc.1"},
+	}
+
+	for _, tc := range tests {
+		v := Must(NewSemver(tc.input))
+		next, err := tc.step(v)
+		if err != nil {
+			t.Errorf("%s on %s unexpected error: %v", tc.name, tc.input, err)
+			continue
+		}
+		if next.Original() != tc.expected || next.Scheme() != SemVer2 {
+			t.Errorf("%s on %s: expected SemVer2 %s, got %s %s", tc.name, tc.input, tc.expected, next.Scheme(), next.Original())
+		}
+		if len(next.Segments64()) != 3 {
+			t.Errorf("%s on %s: expected 3 segments, got %v", tc.name, tc.input, next.Segments64())
+		}
+		if !next.GreaterThan(v) {
+			t.Errorf("%s on %s: %s is not greater than the input", tc.name, tc.input, next.Original())
+		}
+	}
+
+	if _, err := Must(NewSemver("1.0.0-dev")).Promote(); err == nil {
+		t.Error("Promote(1.0.0-dev): expected error, alpha.1 sorts before dev")
+	}
+	if _, err := Must(NewSemver("1.2.3")).Bump(BumpBuild); err == nil {
+		t.Error("Bump(1.2.3, build): expected error for a SemVer2 version")
+	}
+}
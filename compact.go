@@ -0,0 +1,333 @@
+package version
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// This file holds constraint compaction and the renderer that turns a domain
+// union back into Composer constraint syntax.
+
+// matchNoneConstraint is the rendering of an empty version set: no numeric
+// version lies below the lowest one and ordering operators never match
+// branches.
+const matchNoneConstraint = "<0.0.0.0-dev"
+
+// Compact returns the shortest equivalent form of constraint, like
+// Composer's Intervals::compactConstraint. Redundant clauses collapse
+// (">=1.0 >=1.2" becomes ">=1.2"), overlapping or adjacent OR groups merge
+// ("^1.0 || ^1.5" becomes "^1.0") and impossible groups are dropped. Ranges
+// are written as ^, ~ or .* where one fits and as ">=a <b" otherwise.
+//
+// The result matches exactly the versions the input matches. When no shorter
+// form does, as for "@beta" or "<=2.0 !=2.0", whose 2.0 prereleases
+// "<2.0-stable" would reject, Compact returns the constraint as written.
+func Compact(constraint string) (string, error) {
+	constraint = normalizeConstraintInput(constraint)
+	original, err := NewConstraint(constraint)
+	if err != nil {
+		return "", err
+	}
+	domains, err := original.domains()
+	if err != nil {
+		return "", err
+	}
+
+	compacted, ok := renderDomains(domains)
+	if !ok || len(compacted) > len(constraint) || !sameVersions(original, domains, compacted) {
+		return constraint, nil
+	}
+	return compacted, nil
+}
+
+// sameVersions reports whether rendered matches the same versions as cs,
+// whose domains are given. Equal domains are not enough: Check treats a few
+// versions differently from the domain model, such as the prereleases of a
+// "-stable" bound and every version a bare "@stability" rejects, so Check
+// must also agree on the versions around each term of both constraints.
+func sameVersions(cs Constraints, domains []constraintDomain, rendered string) bool {
+	parsed, err := NewConstraint(rendered)
+	if err != nil {
+		return false
+	}
+	parsedDomains, err := parsed.domains()
+	if err != nil || !domainUnionsEquivalent(domains, parsedDomains) {
+		return false
+	}
+
+	for _, v := range checkProbes(cs, parsed) {
+		if cs.Check(v) != parsed.Check(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// probeStabilities are the suffixes checkProbes puts on each term version,
+// one per stability Check can tell apart.
+var probeStabilities = []string{"-dev", "-alpha1", "-beta1", "-RC1", "", "-patch1"}
+
+// checkProbes returns the versions sameVersions checks: every stability of
+// each numeric term version and of 1.0, every branch the constraints name,
+// and one branch they do not.
+func checkProbes(sets ...Constraints) []*Version {
+	bases := []string{"1.0"}
+	probes := []*Version{Must(NewVersion("dev-compact-probe"))}
+	for _, cs := range sets {
+		for _, group := range cs {
+			for _, c := range group {
+				switch {
+				case c.check == nil:
+				case c.check.branch != "" || hasWildcardSegment(c.check):
+					probes = append(probes, c.check)
+				default:
+					bases = append(bases, renderVersion(&Version{segments: c.check.segments}))
+				}
+			}
+		}
+	}
+
+	for _, base := range bases {
+		for _, suffix := range probeStabilities {
+			if v, err := NewVersion(base + suffix); err == nil {
+				probes = append(probes, v)
+			}
+		}
+	}
+	return probes
+}
+
+// renderDomains renders the union of domains as a constraint string: one OR
+// group per merged numeric interval, followed by one per included branch.
+//
+// Only "!=" and "*" match every branch, so a union matching every branch is
+// written as the "!=" terms for what it leaves out, such as "!=1.* !=dev-foo".
+// When the numeric versions it leaves out are not points or wildcard ranges
+// this is impossible: ok is false and the rendering keeps only the numeric
+// versions. Constraints never parse to such a union, but the set operations
+// can produce one.
+func renderDomains(domains []constraintDomain) (rendered string, ok bool) {
+	branches := snapshotBranches(domains)
+	if branches.Exclude {
+		if rendered, ok := renderExclusions(domains, branches.Names); ok {
+			return rendered, true
+		}
+	}
+
+	var groups []string
+	intervals := joinExcludedPoints(mergeNumericIntervals(unionNumericIntervals(domains)))
+	for _, interval := range intervals {
+		groups = append(groups, renderInterval(interval))
+	}
+	if !branches.Exclude {
+		groups = append(groups, branches.Names...)
+	}
+	if len(groups) == 0 {
+		return matchNoneConstraint, !branches.Exclude
+	}
+	return strings.Join(groups, " || "), !branches.Exclude
+}
+
+// renderExclusions renders a union matching every branch but names as a
+// single group of "!=" terms, one per numeric version or wildcard range the
+// union leaves out and one per excluded branch.
+func renderExclusions(domains []constraintDomain, names []string) (string, bool) {
+	var terms []string
+	for _, gap := range complementDomain(domains).numeric {
+		lower, upper := gap.lower, gap.upper
+		switch {
+		case lower != nil && upper != nil && lower.inclusive && upper.inclusive && lower.version.Equal(upper.version):
+			terms = append(terms, "!="+renderVersion(lower.version))
+		default:
+			wildcard, ok := renderWildcardRange(gap)
+			if !ok {
+				return "", false
+			}
+			terms = append(terms, "!="+wildcard)
+		}
+	}
+	for _, name := range names {
+		terms = append(terms, "!="+name)
+	}
+	if len(terms) == 0 {
+		return "*", true
+	}
+	return strings.Join(terms, " "), true
+}
+
+func renderInterval(interval versionInterval) string {
+	var terms []string
+	if term, ok := renderShorthandRange(interval); ok {
+		terms = append(terms, term)
+	} else {
+		lower, upper := interval.lower, interval.upper
+		switch {
+		case lower != nil && upper != nil && lower.inclusive && upper.inclusive && lower.version.Equal(upper.version):
+			terms = append(terms, renderVersion(lower.version))
+		case lower == nil && upper == nil:
+			// "*" and a bare "!=" would also match every branch.
+			terms = append(terms, ">=0.0")
+		default:
+			if lower != nil {
+				terms = append(terms, renderLowerBound(lower))
+			}
+			if upper != nil {
+				terms = append(terms, renderUpperBound(upper))
+			}
+		}
+	}
+
+	for _, excluded := range interval.exclusions {
+		terms = append(terms, "!="+renderVersion(excluded))
+	}
+	return strings.Join(terms, " ")
+}
+
+// renderShorthandRange writes the interval as ^a.b, ~a.b.c or a.b.* when its
+// bounds are exactly the ones such a constraint produces.
+func renderShorthandRange(interval versionInterval) (string, bool) {
+	lower, upper := interval.lower, interval.upper
+	if lower == nil || upper == nil || !lower.inclusive || upper.inclusive ||
+		!isPlainDevBound(lower.version) || !isPlainDevBound(upper.version) {
+		return "", false
+	}
+	l, u := lower.version.segments, upper.version.segments
+
+	switch {
+	case l[0] > 0 && u[0] == l[0]+1 && u[1] == 0 && u[2] == 0:
+		return "^" + renderSegments(l[:3], 2), true
+	case l[0] == 0 && l[1] > 0 && u[0] == 0 && u[1] == l[1]+1 && u[2] == 0:
+		return "^" + renderSegments(l[:3], 2), true
+	case l[0] == 0 && l[1] == 0 && u[0] == 0 && u[1] == 0 && u[2] == l[2]+1:
+		return "^" + renderSegments(l[:3], 3), true
+	case u[0] == l[0] && u[1] == l[1]+1 && u[2] == 0:
+		if l[2] == 0 {
+			return renderSegments(l[:2], 2) + ".*", true
+		}
+		return "~" + renderSegments(l[:3], 3), true
+	default:
+		return "", false
+	}
+}
+
+// renderWildcardRange writes the interval as a.b.* when its bounds are the
+// ones such a wildcard produces. A missing lower bound is 0.0.0.0-dev.
+func renderWildcardRange(interval versionInterval) (string, bool) {
+	lower, upper := interval.lower, interval.upper
+	if upper == nil || upper.inclusive || !isPlainDevBound(upper.version) {
+		return "", false
+	}
+	l, u := []int64{0, 0, 0, 0}, upper.version.segments
+	if lower != nil {
+		if !lower.inclusive || !isPlainDevBound(lower.version) {
+			return "", false
+		}
+		l = lower.version.segments
+	}
+	for fixed := 1; fixed <= 3; fixed++ {
+		if slices.Equal(l[:fixed-1], u[:fixed-1]) && u[fixed-1] == l[fixed-1]+1 && allZero(l[fixed:]) && allZero(u[fixed:]) {
+			return renderSegments(l[:fixed], fixed) + ".*", true
+		}
+	}
+	return "", false
+}
+
+// isPlainDevBound reports whether v is a bare x.y.z.0-dev bound of the kind
+// implicit dev bounds produce.
+func isPlainDevBound(v *Version) bool {
+	return v.branch == "" && v.pre == "dev" && len(v.segments) >= 4 && v.segments[3] == 0 && !hasWildcardSegment(v)
+}
+
+// renderLowerBound relies on ">=" adding an implicit -dev to stable versions:
+// ">=1.2" starts at 1.2.0.0-dev, while a stable inclusive bound needs an
+// explicit "-stable" to suppress it.
+func renderLowerBound(bound *versionBound) string {
+	if !bound.inclusive {
+		return ">" + renderVersion(bound.version)
+	}
+	if implicit, ok := renderImplicitDev(bound.version); ok {
+		return ">=" + implicit
+	}
+	if !bound.version.IsPrerelease() {
+		return ">=" + renderVersion(bound.version) + "-stable"
+	}
+	return ">=" + renderVersion(bound.version)
+}
+
+// renderUpperBound is renderLowerBound for "<", which also adds an implicit
+// -dev, and "<=", which does not.
+func renderUpperBound(bound *versionBound) string {
+	if bound.inclusive {
+		return "<=" + renderVersion(bound.version)
+	}
+	if implicit, ok := renderImplicitDev(bound.version); ok {
+		return "<" + implicit
+	}
+	if !bound.version.IsPrerelease() {
+		return "<" + renderVersion(bound.version) + "-stable"
+	}
+	return "<" + renderVersion(bound.version)
+}
+
+// renderImplicitDev renders an x.y.z.w-dev version without its -dev suffix,
+// for operators that add it back.
+func renderImplicitDev(v *Version) (string, bool) {
+	if v.branch != "" || v.pre != "dev" || hasWildcardSegment(v) {
+		return "", false
+	}
+	return renderSegments(v.segments, 2), true
+}
+
+// renderVersion renders v in a short form that parses back to the same
+// version: trailing zero segments beyond the second are dropped.
+func renderVersion(v *Version) string {
+	if v.branch != "" || hasWildcardSegment(v) {
+		return v.Pretty()
+	}
+	rendered := renderSegments(v.segments, 2)
+	if len(v.segments) > 4 {
+		rendered = renderDateSegments(v.segments)
+	}
+	if v.pre != "" {
+		rendered += "-" + v.pre
+	}
+	return rendered
+}
+
+// renderSegments joins segments, dropping trailing zeros but keeping at least
+// min of them.
+func renderSegments(segments []int64, min int) string {
+	end := len(segments)
+	for end > min && segments[end-1] == 0 {
+		end--
+	}
+	parts := make([]string, end)
+	for i := range parts {
+		parts[i] = strconv.FormatInt(segments[i], 10)
+	}
+	return strings.Join(parts, ".")
+}
+
+// renderDateSegments renders the segments of a date version such as
+// 2010.01.02.10.20.30.5, the only versions with more than four segments. They
+// parse back only with the fields after the year written as two digits.
+func renderDateSegments(segments []int64) string {
+	parts := make([]string, len(segments))
+	for i, segment := range segments {
+		parts[i] = strconv.FormatInt(segment, 10)
+		if i > 0 && i <= 6 && segment < 10 {
+			parts[i] = "0" + parts[i]
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+func hasWildcardSegment(v *Version) bool {
+	for _, segment := range v.segments {
+		if segment == branchWildcardSegment {
+			return true
+		}
+	}
+	return false
+}
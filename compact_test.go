@@ -0,0 +1,116 @@
+package version
+
+import "testing"
+
+func TestCompact(t *testing.T) {
+	tests := []struct {
+		constraint string
+		expected   string
+	}{
+		{">=1.0,>=1.2", ">=1.2"},
+		{"^1.0 || ^1.5", "^1.0"},
+		{"^1.0 || ^2.0", ">=1.0 <3.0"},
+		{"^1.0 || ^3.0", "^1.0 || ^3.0"},
+		{">=1.0 <1.5 || >=1.5 <2.0", "^1.0"},
+		{"^1.2 || >2.0 <1.0", "^1.2"},
+		{"~1.2.3", "~1.2.3"},
+		{"~1.2", "^1.2"},
+		{"1.2.* || 1.3.*", ">=1.2 <1.4"},
+		{"^0.3 || ^0.3.5", "^0.3"},
+		{"^0.0.3", "^0.0.3"},
+		{">=1.0 <=2.0", ">=1.0 <=2.0"},
+		{"1.0 - 2.0", "1.0 - 2.0"},
+		{">1.0 <2.0-beta1", ">1.0 <2.0-beta1"},
+		{"1.2.3 || 1.2.3", "1.2.3"},
+		{"^1.2, !=1.5.0, !=1.6.0", "^1.2 !=1.5 !=1.6"},
+		{"!=1.5.0", "!=1.5"},
+		{"*", "*"},
+		{"", "*"},
+		{"!=dev-main", "!=dev-main"},
+		{"!=1.0, !=dev-main", "!=1.0 !=dev-main"},
+		{">1.0 || <1.2", ">1.0 || <1.2"},
+		{">=0.0 <1.2 || >1.0", ">=0.0"},
+		{">3.0 || <3.0-stable", ">3.0 || <3.0-stable"},
+		{"1.2.* || !=1.*", "1.2.* || !=1.*"},
+		{"<=2.0 !=2.0", "<=2.0 !=2.0"},
+		{"@beta", "@beta"},
+		{"^1.0@beta || ^1.2", ">=1.0-beta <2.0"},
+		{"!=1.*", "!=1.*"},
+		{"!=0.*, !=1.2.*, !=2.0.0, !=dev-foo", "!=0.* !=1.2.* !=2.0 !=dev-foo"},
+		{"!=1.*, >=0.5", "!=1.*, >=0.5"},
+		{"^0", "^0"},
+		{"^0 || 0.5.*", ">=0.0 <1.0"},
+		{"dev-main || dev-main || ^1.0", "^1.0 || dev-main"},
+		{">2.0 <1.0", ">2.0 <1.0"},
+		{">=1.0.0-stable", ">=1.0-stable"},
+		{">=1.0@beta <2.0", ">=1.0-beta <2.0"},
+		{"1.x-dev", "1.x-dev"},
+		{"2010.01.02.10.20.30.5 || 2010.01.02.10.20.30.5", "2010.01.02.10.20.30.05"},
+	}
+
+	for _, tc := range tests {
+		actual, err := Compact(tc.constraint)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", tc.constraint, err)
+			continue
+		}
+		if actual != tc.expected {
+			t.Errorf("%q: expected %q, got %q", tc.constraint, tc.expected, actual)
+		}
+	}
+
+	if _, err := Compact(">>1.0"); err == nil {
+		t.Error("expected error for malformed constraint")
+	}
+}
+
+func TestCompactIsEquivalent(t *testing.T) {
+	constraints := append([]string{
+		"^1.2 || ~1.4.5 || 2.0.*",
+		">=1.0 <1.1 || >1.1 <2.0",
+		"^1.0, !=1.2.0 || ^1.1",
+		">=0.5 <=0.9 || 0.9.1",
+		"~0.0.4 || ^0.1",
+		"!=1.0, !=dev-foo || dev-bar",
+		">=1.0-alpha2 <1.0-RC1 || 1.0.0",
+		"3.x-dev || >=4.0 <4.5",
+		"<=2.0 !=2.0",
+		">3.0 || <3.0-stable",
+		"1.2.* || !=1.*",
+		"@beta",
+	}, constraintSeeds...)
+	versions := []string{
+		"0.0.4", "0.1.0", "0.5.0", "0.9.0", "0.9.1", "1.0.0-alpha2", "1.0.0-beta1", "1.0.0",
+		"1.1.0", "1.2.0", "1.2.0-dev", "1.4.7", "1.5.0", "2.0.0-RC1", "2.0.3", "3.0.0",
+		"4.2.0", "2.0.0-beta1", "3.0.0-beta1", "dev-main", "dev-foo", "dev-bar",
+	}
+
+	for _, constraint := range constraints {
+		original, err := NewConstraint(normalizeConstraintInput(constraint))
+		if err != nil {
+			continue
+		}
+		compacted, err := Compact(constraint)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", constraint, err)
+			continue
+		}
+
+		left, _ := original.domains()
+		right, err := constraintUnionDomains(compacted)
+		if err != nil {
+			t.Errorf("%q: compacted to %q which does not parse: %s", constraint, compacted, err)
+			continue
+		}
+		if !domainUnionsEquivalent(left, right) {
+			t.Errorf("%q: compacted to %q which matches a different set", constraint, compacted)
+		}
+		reparsed := MustConstraints(NewConstraint(compacted))
+		for _, raw := range versions {
+			v := Must(NewVersion(raw))
+			if original.Check(v) != reparsed.Check(v) {
+				t.Errorf("%q vs %s: compacted %q disagrees", constraint, raw, compacted)
+			}
+		}
+	}
+}
@@ -16,6 +16,9 @@ type Constraint struct {
 	origSegments int    // Number of segments in the original constraint string
 	operator     string // The operator used (e.g., "~", "^", ">=", etc.)
 	stableBound  bool
+	flag         string // The @stability flag as written, canonicalized
+	wildcard     bool   // Matches the segments of check as a prefix (1.2.*)
+	matchAll     bool   // Spelled as a match-all such as "x" or "*@dev"
 }
 
 // Constraints is a 2D slice of constraints. We make a custom type so
@@ -57,67 +60,96 @@ func init() {
 
 // NewConstraint will parse one or more constraints from the given
 // constraint string. The string must be a comma or pipe separated
-// list of constraints.
+// list of constraints. Parse failures are reported as *ParseError with
+// the offset of the offending token in cs.
 func NewConstraint(cs string) (Constraints, error) {
-	cs = strings.ReplaceAll(cs, "||", "|")
-	ors := strings.Split(cs, "|")
+	return newConstraint(cs, Composer)
+}
+
+func newConstraint(cs string, scheme Scheme) (Constraints, error) {
+	ors, offsets := splitOrConstraints(cs)
 	or := make([][]*Constraint, len(ors))
 	for k, v := range ors {
-		v = stripConstraintAlias(v)
-		// Check for hyphenated range
-		if strings.Contains(v, " - ") && !strings.Contains(v, ",") {
-			hyphenConstraints, err := parseHyphenRange(v)
+		constraints, err := parseOrConstraint(v, scheme)
+		if err != nil {
+			return nil, relocateParseError(err, cs, offsets[k])
+		}
+		or[k] = constraints
+	}
+
+	return Constraints(or), nil
+}
+
+// splitOrConstraints splits cs on "|" and "||" and reports the byte offset of
+// each alternative in cs.
+func splitOrConstraints(cs string) ([]string, []int) {
+	var ors []string
+	var offsets []int
+	start := 0
+	for i := 0; i < len(cs); i++ {
+		if cs[i] != '|' {
+			continue
+		}
+		ors = append(ors, cs[start:i])
+		offsets = append(offsets, start)
+		if i+1 < len(cs) && cs[i+1] == '|' {
+			i++
+		}
+		start = i + 1
+	}
+	return append(ors, cs[start:]), append(offsets, start)
+}
+
+func parseOrConstraint(v string, scheme Scheme) ([]*Constraint, error) {
+	v = stripConstraintAlias(v)
+	// Check for hyphenated range
+	if strings.Contains(v, " - ") && !strings.Contains(v, ",") {
+		return parseHyphenRange(v, scheme)
+	}
+
+	trimmed := strings.TrimSpace(v)
+	vs, err := splitAndConstraints(trimmed)
+	if err != nil {
+		return nil, relocateParseError(err, v, strings.Index(v, trimmed))
+	}
+	result := make([]*Constraint, 0, len(vs))
+	for _, single := range vs {
+		if strings.Contains(single, " - ") {
+			hyphenConstraints, err := parseHyphenRange(single, scheme)
 			if err != nil {
 				return nil, err
 			}
-			or[k] = hyphenConstraints
+			result = append(result, hyphenConstraints...)
 			continue
 		}
 
-		v = strings.TrimSpace(v)
-		vs, err := splitAndConstraints(v)
+		c, err := parseSingle(single, scheme)
 		if err != nil {
 			return nil, err
 		}
-		result := make([]*Constraint, 0, len(vs))
-		for _, single := range vs {
-			if strings.Contains(single, " - ") {
-				hyphenConstraints, err := parseHyphenRange(single)
-				if err != nil {
-					return nil, err
-				}
-				result = append(result, hyphenConstraints...)
-				continue
-			}
 
-			c, err := parseSingle(single)
-			if err != nil {
-				return nil, err
-			}
-
-			result = append(result, c)
-		}
-		or[k] = result
+		result = append(result, c)
 	}
-
-	return Constraints(or), nil
+	return result, nil
 }
 
-func parseHyphenRange(v string) ([]*Constraint, error) {
+func parseHyphenRange(v string, scheme Scheme) ([]*Constraint, error) {
 	parts := strings.Split(v, " - ")
 	if len(parts) != 2 {
-		return nil, fmt.Errorf("malformed constraint: %s", v)
+		return nil, newParseError(ErrInvalidHyphenRange, v, strings.TrimSpace(v), "malformed constraint: "+v)
 	}
-	if invalidHyphenWildcard(strings.TrimSpace(parts[0])) || invalidHyphenWildcard(strings.TrimSpace(parts[1])) {
-		return nil, fmt.Errorf("malformed constraint: %s", v)
+	for _, part := range parts {
+		if invalidHyphenWildcard(strings.TrimSpace(part)) {
+			return nil, newParseError(ErrInvalidHyphenRange, v, strings.TrimSpace(part), "malformed constraint: "+v)
+		}
 	}
 
-	lowerBound, err := parseSingle(">=" + strings.TrimSpace(parts[0]))
+	lowerBound, err := parseSingle(">="+strings.TrimSpace(parts[0]), scheme)
 	if err != nil {
 		return nil, err
 	}
 
-	upperBound, err := parseHyphenUpperBound(strings.TrimSpace(parts[1]))
+	upperBound, err := parseHyphenUpperBound(strings.TrimSpace(parts[1]), scheme)
 	if err != nil {
 		return nil, err
 	}
@@ -128,10 +160,15 @@ func parseHyphenRange(v string) ([]*Constraint, error) {
 func splitAndConstraints(v string) ([]string, error) {
 	commaParts := strings.Split(v, ",")
 	var result []string
+	offset := 0
 	for _, commaPart := range commaParts {
+		partOffset := offset
+		offset += len(commaPart) + 1
 		commaPart = strings.TrimSpace(commaPart)
 		if commaPart == "" {
-			return nil, fmt.Errorf("malformed constraint: %s", v)
+			err := malformedConstraint(v, "")
+			err.Offset = partOffset
+			return nil, err
 		}
 		if strings.Contains(commaPart, " - ") {
 			result = append(result, commaPart)
@@ -159,10 +196,10 @@ func stripConstraintAlias(constraint string) string {
 	return constraint
 }
 
-func parseHyphenUpperBound(v string) (*Constraint, error) {
+func parseHyphenUpperBound(v string, scheme Scheme) (*Constraint, error) {
 	parts, ok := simpleVersionParts(v)
 	if !ok || len(parts) >= 3 {
-		return parseSingle("<=" + v)
+		return parseSingle("<="+v, scheme)
 	}
 
 	upper, err := incrementVersionParts(parts)
@@ -170,7 +207,7 @@ func parseHyphenUpperBound(v string) (*Constraint, error) {
 		return nil, err
 	}
 
-	return parseSingle("<" + upper)
+	return parseSingle("<"+upper, scheme)
 }
 
 func invalidHyphenWildcard(v string) bool {
@@ -292,6 +329,9 @@ func (c *Constraint) Check(v *Version) bool {
 			return false
 		}
 	}
+	if c.check != nil && c.check.scheme == SemVer2 {
+		return c.checkSemver(v)
+	}
 	if c.stableBound && c.excludesSameVersionPrerelease() && v.IsPrerelease() && equalInt64(v.segments, c.check.segments) {
 		return false
 	}
@@ -311,7 +351,7 @@ func (c *Constraint) String() string {
 	return c.original
 }
 
-func parseSingle(v string) (*Constraint, error) {
+func parseSingle(v string, scheme Scheme) (*Constraint, error) {
 	if strings.TrimSpace(v) == "*" {
 		return &Constraint{
 			f:            constraintWildcard,
@@ -334,12 +374,13 @@ func parseSingle(v string) (*Constraint, error) {
 
 	operator, version, stability, ok := splitConstraintParts(v)
 	if !ok {
-		return nil, fmt.Errorf("malformed constraint: %s", v)
+		return nil, malformedConstraint(v, strings.TrimSpace(v))
 	}
 
 	if stability != "" {
 		if _, ok := stabilityLevels[stability]; !ok {
-			return nil, fmt.Errorf("unknown stability: %s", stability)
+			flag := v[strings.LastIndex(v, "@")+1:]
+			return nil, newParseError(ErrInvalidStability, v, strings.TrimSpace(flag), "unknown stability: "+stability)
 		}
 	}
 
@@ -347,6 +388,7 @@ func parseSingle(v string) (*Constraint, error) {
 	// when it is "stable" (see VersionParser::parseConstraint). The remaining
 	// flags only influence the implicit prerelease suffix appended below, so a
 	// trailing "@stable" must behave exactly like no flag at all.
+	flag := expandStability(stability)
 	if stability == "stable" {
 		stability = ""
 	}
@@ -357,18 +399,40 @@ func parseSingle(v string) (*Constraint, error) {
 	}
 
 	version = normalizeConstraintVersionTypos(version)
+	if isMatchAllDevBranch(version) && (operator == "" || operator == "=" || operator == "==") {
+		// "x-dev" names no branch; it is the dev-stability spelling of "x".
+		version = version[:len(version)-len("-dev")]
+		if flag == "" {
+			flag = StabilityDev
+		}
+	}
 	version = applyStabilitySuffix(version, stability, operator)
 	origSegments := countVersionSegments(version)
 	stableBound := hasStableModifier(version)
 
 	// Handle wildcards in version numbers
 	if isWildcardConstraintVersion(version) && !isNumericDevBranch(version) {
-		return parseWildcardConstraint(operator, version, v, "")
+		c, err := parseWildcardConstraint(operator, version, v, "")
+		if err != nil {
+			return nil, err
+		}
+		c.flag = flag
+		return c, nil
 	}
 
-	check, err := NewVersion(version)
+	var check *Version
+	if scheme == SemVer2 && !strings.HasPrefix(strings.ToLower(version), "dev-") {
+		check, err = parseSemver(version, semverConstraintRegexp)
+	} else {
+		check, err = NewVersion(version)
+	}
 	if err != nil {
-		return nil, err
+		if token := leadingOperatorChars(strings.TrimSpace(v)); len(token) > len(operator) {
+			// The operator was cut at the longest known prefix, so what NewVersion
+			// choked on is the rest of an operator such as ">>" or "=>".
+			return nil, newParseError(ErrUnknownOperator, v, token, err.Error())
+		}
+		return nil, relocateParseError(err, v, 0)
 	}
 
 	return &Constraint{
@@ -378,17 +442,26 @@ func parseSingle(v string) (*Constraint, error) {
 		origSegments: origSegments,
 		operator:     operator,
 		stableBound:  stableBound,
+		flag:         flag,
 	}, nil
 }
 
+// constraintVersionTypos lists the misspellings normalizeConstraintVersionTypos
+// replaces, in order, along with trailing dots.
+var constraintVersionTypos = []struct{ typo, fix string }{
+	{"..dev", "-dev"},
+	{"..DEV", "-DEV"},
+	{"-.dev", "-dev"},
+	{"-.DEV", "-DEV"},
+	{"_-dev", "-dev"},
+	{"_-DEV", "-DEV"},
+}
+
 func normalizeConstraintVersionTypos(version string) string {
 	version = strings.TrimSpace(version)
-	version = strings.ReplaceAll(version, "..dev", "-dev")
-	version = strings.ReplaceAll(version, "..DEV", "-DEV")
-	version = strings.ReplaceAll(version, "-.dev", "-dev")
-	version = strings.ReplaceAll(version, "-.DEV", "-DEV")
-	version = strings.ReplaceAll(version, "_-dev", "-dev")
-	version = strings.ReplaceAll(version, "_-DEV", "-DEV")
+	for _, typo := range constraintVersionTypos {
+		version = strings.ReplaceAll(version, typo.typo, typo.fix)
+	}
 	return strings.TrimRight(version, ".")
 }
 
@@ -439,7 +512,20 @@ func stripDevReference(version, original string) (string, error) {
 		return base, nil
 	}
 
-	return "", fmt.Errorf("malformed constraint: %s", original)
+	return "", malformedConstraint(original, version)
+}
+
+func malformedConstraint(original, token string) *ParseError {
+	return newParseError(ErrMalformedConstraint, original, token, "malformed constraint: "+original)
+}
+
+// leadingOperatorChars returns the run of operator characters s starts with.
+func leadingOperatorChars(s string) string {
+	end := 0
+	for end < len(s) && strings.IndexByte("<>=!~^", s[end]) >= 0 {
+		end++
+	}
+	return s[:end]
 }
 
 func splitConstraintParts(raw string) (operator, version, stability string, ok bool) {
@@ -538,6 +624,15 @@ func isNumericDevBranch(version string) bool {
 	return true
 }
 
+// isMatchAllDevBranch reports whether version is "x-dev", a dev branch
+// constraint without a single fixed segment.
+func isMatchAllDevBranch(version string) bool {
+	if len(version) > 1 && (version[0] == 'v' || version[0] == 'V') {
+		version = version[1:]
+	}
+	return strings.EqualFold(version, "x-dev")
+}
+
 func isWildcardConstraintVersion(version string) bool {
 	base := strings.TrimSpace(version)
 	if len(base) > 1 && (base[0] == 'v' || base[0] == 'V') {
@@ -562,14 +657,15 @@ func isWildcardSegment(part string) bool {
 // It validates the wildcard pattern and creates appropriate constraint functions.
 func parseWildcardConstraint(operator, version, original, stability string) (*Constraint, error) {
 	version = strings.TrimSpace(version)
+	token := version
 	if len(version) > 1 && (version[0] == 'v' || version[0] == 'V') {
 		version = version[1:]
 	}
 	if operator == "^" || operator == "~" {
-		return nil, fmt.Errorf("malformed constraint: %s", original)
+		return nil, malformedConstraint(original, token)
 	}
 	if strings.ContainsAny(version, "-+") {
-		return nil, fmt.Errorf("malformed constraint: %s", original)
+		return nil, malformedConstraint(original, token)
 	}
 
 	parts := strings.Split(version, ".")
@@ -577,7 +673,7 @@ func parseWildcardConstraint(operator, version, original, stability string) (*Co
 	fixed := []int64{}
 	for i, part := range parts {
 		if part == "" {
-			return nil, fmt.Errorf("malformed constraint: %s", original)
+			return nil, malformedConstraint(original, token)
 		}
 		if isWildcardSegment(part) {
 			if wildcardIndex == -1 {
@@ -586,22 +682,22 @@ func parseWildcardConstraint(operator, version, original, stability string) (*Co
 			continue
 		}
 		if wildcardIndex != -1 {
-			return nil, fmt.Errorf("malformed constraint: %s", original)
+			return nil, malformedConstraint(original, token)
 		}
 		segment, err := strconv.ParseInt(part, 10, 64)
 		if err != nil {
-			return nil, fmt.Errorf("malformed constraint: %s", original)
+			return nil, malformedConstraint(original, token)
 		}
 		fixed = append(fixed, segment)
 	}
 
 	if wildcardIndex == -1 {
-		return nil, fmt.Errorf("malformed constraint: %s", original)
+		return nil, malformedConstraint(original, token)
 	}
 
 	if len(fixed) == 0 {
 		if operator != "" && operator != "=" && operator != "==" {
-			return nil, fmt.Errorf("malformed constraint: %s", original)
+			return nil, malformedConstraint(original, token)
 		}
 		if strings.TrimSpace(original) != "*" {
 			check, err := NewVersion("0.0.0-dev")
@@ -615,6 +711,7 @@ func parseWildcardConstraint(operator, version, original, stability string) (*Co
 				stability:    stability,
 				operator:     ">=",
 				origSegments: 1,
+				matchAll:     true,
 			}, nil
 		}
 		return &Constraint{
@@ -663,6 +760,7 @@ func parseWildcardConstraint(operator, version, original, stability string) (*Co
 		stability:    stability,
 		operator:     operator,
 		origSegments: fixedLen + 1,
+		wildcard:     true,
 	}, nil
 }
 
@@ -587,6 +587,8 @@ func TestWildcardWithOperators(t *testing.T) {
 		{"2.*.*", "2.1.3", true},
 		{"2.*.*", "1.1.3", false},
 		{"*.*", "1.2.3", true},
+		{"x-dev", "1.2.3", true},
+		{"x-dev", "3.0-beta", true},
 	}
 
 	for _, tc := range tests {
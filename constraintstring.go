@@ -0,0 +1,193 @@
+package version
+
+import (
+	"strconv"
+	"strings"
+)
+
+// This file holds the normalized and pretty renderings of constraints, next
+// to String, which returns the input as written.
+
+// NormalizedString returns cs in Composer's normalized form: every term is
+// expanded to the bounds it compiles to, with versions in normalized form.
+// "^1.2" renders ">=1.2.0.0-dev <2.0.0.0-dev", "~1.2.3" renders
+// ">=1.2.3.0-dev <1.3.0.0-dev" and "1.0 - 2.0" renders
+// ">=1.0.0.0-dev <2.1.0.0-dev". "-stable" bounds and "#ref" suffixes are
+// kept, so the result parses back to an equivalent constraint. AND terms are
+// separated by a space and OR groups by " || ".
+func (cs Constraints) NormalizedString() string {
+	return cs.render((*Constraint).NormalizedString, " ")
+}
+
+// Pretty returns cs in a canonical form of the input: OR groups separated by
+// " || ", AND terms by ", ", and each term written without inner spaces, with
+// the canonical operator spelling ("=" and "==" dropped, "<>" as "!=") and
+// stability flag. "^1.0 |  >=2.0,<2.5@RC" renders "^1.0 || >=2.0, <2.5@RC".
+// Hyphen ranges are written as their two bounds.
+func (cs Constraints) Pretty() string {
+	return cs.render((*Constraint).Pretty, ", ")
+}
+
+func (cs Constraints) render(term func(*Constraint) string, and string) string {
+	groups := make([]string, len(cs))
+	for i, group := range cs {
+		terms := make([]string, len(group))
+		for j, c := range group {
+			terms[j] = term(c)
+		}
+		groups[i] = strings.Join(terms, and)
+	}
+	return strings.Join(groups, " || ")
+}
+
+// NormalizedString returns c expanded to the bounds it compiles to; see
+// Constraints.NormalizedString. A "!=" wildcard such as "!=1.*" matches two
+// ranges and keeps its wildcard form.
+func (c *Constraint) NormalizedString() string {
+	flag := ""
+	if c.stability != "" {
+		flag = "@" + expandStability(c.stability)
+	}
+	if c.check == nil {
+		if flag != "" {
+			return flag
+		}
+		return "*"
+	}
+	if c.check.scheme == SemVer2 {
+		return c.normalizedSemverString()
+	}
+
+	operator := canonicalOperator(c.operator)
+	switch {
+	case c.check.branch != "":
+		if operator == "" {
+			operator = "=="
+		}
+		return operator + c.check.branch + c.reference() + flag
+	case isConstraintWildcard(c):
+		if c.origSegments <= 1 {
+			return "*" + flag
+		}
+		lower, upper := wildcardBounds(c)
+		switch operator {
+		case ">=":
+			return ">=" + lower.NormalizedString() + flag
+		case ">":
+			return ">=" + upper.NormalizedString() + flag
+		case "<=":
+			return "<" + upper.NormalizedString() + flag
+		case "<":
+			return "<" + lower.NormalizedString() + flag
+		case "!=":
+			return c.Pretty()
+		default:
+			return ">=" + lower.NormalizedString() + " <" + upper.NormalizedString() + flag
+		}
+	}
+
+	switch operator {
+	case "^":
+		return ">=" + c.normalizedLowerBound() + " <" + implicitDevVersion(caretUpperBound(c)).NormalizedString() + flag
+	case "~":
+		return ">=" + c.normalizedLowerBound() + " <" + implicitDevVersion(tildeUpperBound(c)).NormalizedString() + flag
+	case ">=":
+		return ">=" + c.normalizedLowerBound() + flag
+	case "<":
+		return "<" + c.normalizedUpperBound() + flag
+	case "":
+		return "==" + c.normalizedCheck() + c.reference() + flag
+	default:
+		return operator + c.normalizedCheck() + c.reference() + flag
+	}
+}
+
+// normalizedCheck returns the version of c in normalized form. A "-stable"
+// version keeps its suffix, since it excludes the prereleases of the version
+// that "1.2.0.0" admits.
+func (c *Constraint) normalizedCheck() string {
+	if c.stableBound {
+		return c.check.NormalizedString() + "-stable"
+	}
+	return c.check.NormalizedString()
+}
+
+func (c *Constraint) normalizedLowerBound() string {
+	if c.stableBound {
+		return c.normalizedCheck()
+	}
+	return implicitDevLowerBound(c).NormalizedString()
+}
+
+func (c *Constraint) normalizedUpperBound() string {
+	if c.stableBound {
+		return c.normalizedCheck()
+	}
+	return implicitDevUpperBound(c).NormalizedString()
+}
+
+// reference returns the "#ref" suffix of a dev branch term such as
+// "dev-main#abc123", or "" if it has none.
+func (c *Constraint) reference() string {
+	_, version, _, ok := splitConstraintParts(c.original)
+	if !ok {
+		return ""
+	}
+	if i := strings.Index(version, "#"); i >= 0 {
+		return version[i:]
+	}
+	return ""
+}
+
+// normalizedSemverString expands caret and tilde ranges of SemVer2
+// constraints to an upper bound below the first prerelease of the next
+// release, as checkSemver applies them.
+func (c *Constraint) normalizedSemverString() string {
+	operator := canonicalOperator(c.operator)
+	switch operator {
+	case "^", "~":
+		upper := c.semverUpperBound()
+		parts := []string{"0", "0", "0"}
+		for i, segment := range upper {
+			parts[i] = strconv.FormatInt(segment, 10)
+		}
+		return ">=" + c.check.NormalizedString() + " <" + strings.Join(parts, ".") + "-0"
+	case "":
+		return "==" + c.check.NormalizedString()
+	default:
+		return operator + c.check.NormalizedString()
+	}
+}
+
+// Pretty returns c in canonical form; see Constraints.Pretty.
+func (c *Constraint) Pretty() string {
+	flag := ""
+	if stability := c.Stability(); stability != "" {
+		flag = "@" + stability
+	}
+	if c.check == nil {
+		if c.stability != "" {
+			// A bare "@stability".
+			return flag
+		}
+		return "*" + flag
+	}
+	operator, version, _, ok := splitConstraintParts(c.original)
+	if !ok {
+		return strings.TrimSpace(c.original)
+	}
+	return canonicalOperator(operator) + version + flag
+}
+
+// canonicalOperator returns the spelling of operator that Pretty and
+// NormalizedString use: "" for equality and "!=" for inequality.
+func canonicalOperator(operator string) string {
+	switch operator {
+	case "=", "==":
+		return ""
+	case "<>":
+		return "!="
+	default:
+		return operator
+	}
+}
@@ -0,0 +1,107 @@
+package version
+
+import "testing"
+
+func TestConstraintsNormalizedString(t *testing.T) {
+	tests := []struct {
+		constraint string
+		expected   string
+	}{
+		{"^1.2", ">=1.2.0.0-dev <2.0.0.0-dev"},
+		{"~1.2.3", ">=1.2.3.0-dev <1.3.0.0-dev"},
+		{"~1.2", ">=1.2.0.0-dev <2.0.0.0-dev"},
+		{"^0.3", ">=0.3.0.0-dev <0.4.0.0-dev"},
+		{"^0", ">=0.0.0.0-dev <1.0.0.0-dev"},
+		{"1.0 - 2.0", ">=1.0.0.0-dev <2.1.0.0-dev"},
+		{"1.0.0 - 2.0.0", ">=1.0.0.0-dev <=2.0.0.0"},
+		{"1.2.*", ">=1.2.0.0-dev <1.3.0.0-dev"},
+		{">=1.2.*", ">=1.2.0.0-dev"},
+		{"!=1.*", "!=1.*"},
+		{">1.0 <=2.0", ">1.0.0.0 <=2.0.0.0"},
+		{">=1.0-stable", ">=1.0.0.0-stable"},
+		{"^1.2-stable", ">=1.2.0.0-stable <2.0.0.0-dev"},
+		{"~1.2.3-stable", ">=1.2.3.0-stable <1.3.0.0-dev"},
+		{"1.2-stable", "==1.2.0.0-stable"},
+		{"<2.0-stable", "<2.0.0.0-stable"},
+		{">1.2-stable", ">1.2.0.0-stable"},
+		{">=1.0@beta", ">=1.0.0.0-beta"},
+		{">=1.0@stable", ">=1.0.0.0-dev"},
+		{"1.2.3", "==1.2.3.0"},
+		{"<>1.2.3", "!=1.2.3.0"},
+		{"^1.0 || dev-main", ">=1.0.0.0-dev <2.0.0.0-dev || ==dev-main"},
+		{"!=dev-main", "!=dev-main"},
+		{"dev-main#abc123", "==dev-main#abc123"},
+		{"1.0.x-dev#abc123", "==1.0.9999999.9999999-dev#abc123"},
+		{"*", "*"},
+		{"@beta", "@beta"},
+		{"^1.2, !=1.5.0 ||  ~2.1", ">=1.2.0.0-dev <2.0.0.0-dev !=1.5.0.0 || >=2.1.0.0-dev <3.0.0.0-dev"},
+	}
+
+	for _, tc := range tests {
+		cs := MustConstraints(NewConstraint(tc.constraint))
+		actual := cs.NormalizedString()
+		if actual != tc.expected {
+			t.Errorf("%q: expected %q, got %q", tc.constraint, tc.expected, actual)
+			continue
+		}
+
+		equivalent, err := ConstraintEquivalent(tc.constraint, actual)
+		if err != nil {
+			t.Errorf("%q: normalized form %q does not parse: %s", tc.constraint, actual, err)
+		} else if !equivalent {
+			t.Errorf("%q: normalized form %q matches a different set", tc.constraint, actual)
+		}
+	}
+}
+
+func TestConstraintsNormalizedStringSemver(t *testing.T) {
+	tests := []struct {
+		constraint string
+		expected   string
+	}{
+		{"^1.2", ">=1.2.0 <2.0.0-0"},
+		{"^0.3.1", ">=0.3.1 <0.4.0-0"},
+		{"~1.2.3", ">=1.2.3 <1.3.0-0"},
+		{"1.0.0-rc.1", "==1.0.0-rc.1"},
+		{">=1.0.0", ">=1.0.0"},
+	}
+
+	for _, tc := range tests {
+		cs, err := NewConstraintWithOptions(tc.constraint, ParseOptions{Scheme: SemVer2})
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %s", tc.constraint, err)
+		}
+		if actual := cs.NormalizedString(); actual != tc.expected {
+			t.Errorf("%q: expected %q, got %q", tc.constraint, tc.expected, actual)
+		}
+	}
+}
+
+func TestConstraintsPretty(t *testing.T) {
+	tests := []struct {
+		constraint string
+		expected   string
+	}{
+		{"^1.0 |  >=2.0,<2.5@RC", "^1.0 || >=2.0, <2.5@RC"},
+		{"^1.0||^2.0", "^1.0 || ^2.0"},
+		{">= 1.0 < 2.0", ">=1.0, <2.0"},
+		{"== 1.2.3", "1.2.3"},
+		{"<>1.2.3", "!=1.2.3"},
+		{"1.0 - 2.0", ">=1.0, <2.1.0"},
+		{"*", "*"},
+		{"*@beta", "*@beta"},
+		{"@dev", "@dev"},
+		{"~1.2@stable", "~1.2@stable"},
+		{"dev-main as 1.0.x-dev", "dev-main"},
+	}
+
+	for _, tc := range tests {
+		cs := MustConstraints(NewConstraint(tc.constraint))
+		if actual := cs.Pretty(); actual != tc.expected {
+			t.Errorf("%q: expected %q, got %q", tc.constraint, tc.expected, actual)
+		}
+		if reparsed := MustConstraints(NewConstraint(cs.Pretty())).Pretty(); reparsed != tc.expected {
+			t.Errorf("%q: pretty form is not stable, got %q on reparse", tc.constraint, reparsed)
+		}
+	}
+}
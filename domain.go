@@ -90,7 +90,13 @@ func allConstraintDomain() constraintDomain {
 	}
 }
 
+// numericOnlyDomain returns the domain matching the numeric versions in
+// interval. An interval ending before 0.0.0.0-dev, as for "<0.0.0.0-dev",
+// holds no version, so its domain is empty like that of ">2.0 <1.0".
 func numericOnlyDomain(interval versionInterval) constraintDomain {
+	if interval.upper != nil && !interval.upper.inclusive && isLowestVersion(interval.upper.version) {
+		return constraintDomain{}
+	}
 	return constraintDomain{
 		numeric:       []versionInterval{interval},
 		branches:      map[string]struct{}{},
@@ -98,6 +104,12 @@ func numericOnlyDomain(interval versionInterval) constraintDomain {
 	}
 }
 
+// isLowestVersion reports whether v is 0.0.0.0-dev, below which there is no
+// version.
+func isLowestVersion(v *Version) bool {
+	return v.branch == "" && v.pre == "dev" && allZero(v.segments)
+}
+
 func branchOnlyDomain(branch string) constraintDomain {
 	return constraintDomain{
 		branches:      map[string]struct{}{branch: {}},
@@ -110,6 +122,9 @@ func isConstraintWildcard(c *Constraint) bool {
 		return true
 	}
 	_, version, _, ok := splitConstraintParts(c.original)
+	if i := strings.Index(version, "#"); i >= 0 {
+		version = version[:i]
+	}
 	return ok && c.origSegments > 1 && isWildcardConstraintVersion(version) && !isNumericDevBranch(version)
 }
 
@@ -119,9 +134,7 @@ func wildcardConstraintDomain(c *Constraint) constraintDomain {
 		return allConstraintDomain()
 	}
 
-	lower := implicitDevVersion(prefixStart(c.check, fixedLen))
-	upper := implicitDevVersion(prefixEnd(c.check, fixedLen))
-
+	lower, upper := wildcardBounds(c)
 	switch c.operator {
 	case ">=":
 		return numericOnlyDomain(versionInterval{lower: inclusiveBound(lower)})
@@ -132,7 +145,13 @@ func wildcardConstraintDomain(c *Constraint) constraintDomain {
 	case "<":
 		return numericOnlyDomain(versionInterval{upper: exclusiveBound(lower)})
 	case "!=", "<>":
-		return allConstraintDomain()
+		// Every branch and every numeric version outside the wildcard range.
+		domain := allConstraintDomain()
+		domain.numeric = []versionInterval{{lower: inclusiveBound(upper)}}
+		if !isLowestVersion(lower) {
+			domain.numeric = append([]versionInterval{{upper: exclusiveBound(lower)}}, domain.numeric...)
+		}
+		return domain
 	default:
 		return numericOnlyDomain(versionInterval{
 			lower: inclusiveBound(lower),
@@ -141,6 +160,13 @@ func wildcardConstraintDomain(c *Constraint) constraintDomain {
 	}
 }
 
+// wildcardBounds returns where the versions sharing the fixed prefix of a
+// wildcard constraint start and end: 1.2.0.0-dev and 1.3.0.0-dev for 1.2.*.
+func wildcardBounds(c *Constraint) (lower, upper *Version) {
+	fixedLen := c.origSegments - 1
+	return implicitDevVersion(prefixStart(c.check, fixedLen)), implicitDevVersion(prefixEnd(c.check, fixedLen))
+}
+
 func caretConstraintDomain(c *Constraint) constraintDomain {
 	return numericOnlyDomain(versionInterval{
 		lower: inclusiveBound(implicitDevLowerBound(c)),
@@ -180,20 +206,23 @@ func isMajorZeroNumericDevBranchWildcard(c *Constraint) bool {
 }
 
 func tildeConstraintDomain(c *Constraint) constraintDomain {
-	segments := c.check.Segments64()
-	upper := numericVersion(segments[0]+1, 0, 0)
-	if c.origSegments >= 4 {
-		upper = numericVersion(segments[0], segments[1], segments[2]+1)
-	} else if c.origSegments >= 3 {
-		upper = numericVersion(segments[0], segments[1]+1, 0)
-	}
-
 	return numericOnlyDomain(versionInterval{
 		lower: inclusiveBound(implicitDevLowerBound(c)),
-		upper: exclusiveBound(implicitDevVersion(upper)),
+		upper: exclusiveBound(implicitDevVersion(tildeUpperBound(c))),
 	})
 }
 
+func tildeUpperBound(c *Constraint) *Version {
+	segments := c.check.Segments64()
+	if c.origSegments >= 4 {
+		return numericVersion(segments[0], segments[1], segments[2]+1)
+	}
+	if c.origSegments >= 3 {
+		return numericVersion(segments[0], segments[1]+1, 0)
+	}
+	return numericVersion(segments[0]+1, 0, 0)
+}
+
 func implicitDevLowerBound(c *Constraint) *Version {
 	if c.stableBound || c.check.IsPrerelease() {
 		return c.check
@@ -0,0 +1,75 @@
+package version
+
+import (
+	"errors"
+	"strings"
+)
+
+// Sentinel errors reported as the Kind of a *ParseError. Test for them with
+// errors.Is.
+var (
+	ErrMalformedVersion    = errors.New("malformed version")
+	ErrMalformedConstraint = errors.New("malformed constraint")
+	ErrUnknownOperator     = errors.New("unknown operator")
+	ErrInvalidHyphenRange  = errors.New("invalid hyphen range")
+	ErrInvalidStability    = errors.New("invalid stability")
+)
+
+// ParseError describes a version or constraint string that failed to parse.
+// It is returned by NewVersion, NewConstraint and the functions built on
+// them.
+type ParseError struct {
+	// Input is the full string that was being parsed.
+	Input string
+	// Offset is the byte offset of Token within Input.
+	Offset int
+	// Token is the offending part of Input, such as the unknown operator or
+	// the version that did not normalize.
+	Token string
+	// Kind is one of the Err* sentinels.
+	Kind error
+
+	msg string
+}
+
+func newParseError(kind error, input, token string, msg string) *ParseError {
+	offset := strings.Index(input, token)
+	if offset < 0 {
+		offset = 0
+	}
+	return &ParseError{Input: input, Offset: offset, Token: token, Kind: kind, msg: msg}
+}
+
+func (e *ParseError) Error() string {
+	return e.msg
+}
+
+// Unwrap returns Kind so that errors.Is matches the sentinel.
+func (e *ParseError) Unwrap() error {
+	return e.Kind
+}
+
+// relocateParseError rebases a *ParseError raised while parsing a piece of
+// input onto the full input, searching for the token from base onwards. Other
+// errors are returned unchanged.
+func relocateParseError(err error, input string, base int) error {
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Input == input {
+		return err
+	}
+
+	relocated := *parseErr
+	relocated.Input = input
+	relocated.Offset = base
+	if base > len(input) {
+		relocated.Offset = len(input)
+	}
+	if parseErr.Token != "" {
+		if index := strings.Index(input[relocated.Offset:], parseErr.Token); index >= 0 {
+			relocated.Offset += index
+		}
+	} else {
+		relocated.Offset += parseErr.Offset
+	}
+	return &relocated
+}
@@ -0,0 +1,91 @@
+package version
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConstraintParseErrors(t *testing.T) {
+	tests := []struct {
+		constraint string
+		kind       error
+		token      string
+		offset     int
+	}{
+		{">>1.0", ErrUnknownOperator, ">>", 0},
+		{"^1.0 || =>2.0", ErrUnknownOperator, "=>", 8},
+		{">=1.0, !3.0", ErrUnknownOperator, "!", 7},
+		{"^1.0 || >= foo", ErrMalformedVersion, "foo", 11},
+		{"1.0 - 2.0 - 3.0", ErrInvalidHyphenRange, "1.0 - 2.0 - 3.0", 0},
+		{"^1.0 | 1.* - 2.0", ErrInvalidHyphenRange, "1.*", 7},
+		{"1.0 - foo", ErrMalformedVersion, "foo", 6},
+		{">=1.0@gamma", ErrInvalidStability, "gamma", 6},
+		{"^2.0 || ~1.2@Nightly", ErrInvalidStability, "Nightly", 13},
+		{">=1.0,,<2.0", ErrMalformedConstraint, "", 6},
+		{"^1.0 || ^1.*", ErrMalformedConstraint, "1.*", 9},
+		{"1.0#abc", ErrMalformedConstraint, "1.0#abc", 0},
+		{" >=", ErrMalformedConstraint, ">=", 1},
+	}
+
+	for _, tc := range tests {
+		_, err := NewConstraint(tc.constraint)
+		if !errors.Is(err, tc.kind) {
+			t.Errorf("%q: expected %v, got %v", tc.constraint, tc.kind, err)
+			continue
+		}
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("%q: expected *ParseError, got %T", tc.constraint, err)
+			continue
+		}
+		if parseErr.Input != tc.constraint {
+			t.Errorf("%q: expected input %q, got %q", tc.constraint, tc.constraint, parseErr.Input)
+		}
+		if parseErr.Token != tc.token || parseErr.Offset != tc.offset {
+			t.Errorf("%q: expected token %q at %d, got %q at %d", tc.constraint, tc.token, tc.offset, parseErr.Token, parseErr.Offset)
+		}
+		if tc.token != "" && tc.constraint[parseErr.Offset:parseErr.Offset+len(tc.token)] != tc.token {
+			t.Errorf("%q: offset %d does not point at %q", tc.constraint, parseErr.Offset, tc.token)
+		}
+	}
+}
+
+func TestVersionParseErrors(t *testing.T) {
+	tests := []struct {
+		version string
+		token   string
+		offset  int
+	}{
+		{"not a version", "not a version", 0},
+		{"  foo", "foo", 2},
+		{"feature as 1.0", "feature", 0},
+	}
+
+	for _, tc := range tests {
+		_, err := NewVersion(tc.version)
+		if !errors.Is(err, ErrMalformedVersion) {
+			t.Errorf("%q: expected ErrMalformedVersion, got %v", tc.version, err)
+			continue
+		}
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("%q: expected *ParseError, got %T", tc.version, err)
+			continue
+		}
+		if parseErr.Input != tc.version || parseErr.Token != tc.token || parseErr.Offset != tc.offset {
+			t.Errorf("%q: expected token %q at %d, got %+v", tc.version, tc.token, tc.offset, parseErr)
+		}
+	}
+}
+
+func TestParseErrorKeepsMessage(t *testing.T) {
+	_, err := NewConstraint(">=1.0@gamma")
+	if err == nil || err.Error() != "unknown stability: gamma" {
+		t.Errorf("expected unknown stability message, got %v", err)
+	}
+
+	_, err = NewVersion("foo")
+	if err == nil || err.Error() != `invalid version string "foo"` {
+		t.Errorf("expected invalid version string message, got %v", err)
+	}
+}
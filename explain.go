@@ -0,0 +1,219 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// This file holds Explain, which reports why a version does or does not
+// satisfy a set of constraints.
+
+// Reason classifies why a single constraint rejected a version.
+type Reason int
+
+const (
+	ReasonNone            Reason = iota // the constraint matched
+	ReasonBelowLowerBound               // the version is below the range, e.g. 1.0.0 for ^1.2
+	ReasonAboveUpperBound               // the version is above the range, e.g. 2.0.0 for ^1.2
+	ReasonExcluded                      // the version is excluded by "!=" or differs from an exact version
+	ReasonStabilityFlag                 // a bare "@stability" constraint rejects the version's stability
+	ReasonStableBound                   // a "-stable" bound rejects a prerelease of the same version
+	ReasonBranchOrdering                // an ordering operator never matches a branch (dev-*)
+	ReasonBranchMismatch                // the version is not the branch the constraint names
+	ReasonOther                         // the constraint rejects the version for none of the reasons above
+)
+
+// String returns a short name for the reason.
+func (r Reason) String() string {
+	switch r {
+	case ReasonNone:
+		return "none"
+	case ReasonBelowLowerBound:
+		return "below lower bound"
+	case ReasonAboveUpperBound:
+		return "above upper bound"
+	case ReasonExcluded:
+		return "excluded"
+	case ReasonStabilityFlag:
+		return "stability flag"
+	case ReasonStableBound:
+		return "stable bound"
+	case ReasonBranchOrdering:
+		return "branch ordering"
+	case ReasonBranchMismatch:
+		return "branch mismatch"
+	case ReasonOther:
+		return "other"
+	default:
+		return "Reason(" + strconv.Itoa(int(r)) + ")"
+	}
+}
+
+// Explanation is the result of Explain: whether the version is satisfied and
+// how each OR group of the constraints decided.
+type Explanation struct {
+	Version   *Version
+	Satisfied bool
+	Groups    []GroupExplanation
+}
+
+// GroupExplanation describes one OR group. When the group rejects the
+// version, Term is the first AND term that did, Reason classifies why and
+// Message says it in words. Note, whether or not the group matched, points
+// out an "@stable" flag on a version constraint, which Check ignores.
+type GroupExplanation struct {
+	Constraint string
+	Satisfied  bool
+	Term       *Constraint
+	Reason     Reason
+	Message    string
+	Note       string
+}
+
+// Explain checks v against cs like Check, and reports for every OR group
+// which term rejected v and why:
+//
+//	1.0.0 is below the lower bound >=1.2.0.0-dev of ^1.2
+//	1.2.0-beta1 is a prerelease of 1.2.0 and ^1.2-stable excludes prereleases
+//	stability flag @stable rejects beta version 1.3.0-beta1
+//	ordering operator > never matches branch dev-main
+//
+// An "@stable" flag attached to a version constraint, as in ^1.2@stable,
+// only sets the minimum stability for dependency resolution. Check ignores
+// it, so ^1.2@stable matches 1.3.0-beta1; the group's Note says so.
+func (cs Constraints) Explain(v *Version) Explanation {
+	explanation := Explanation{Version: v}
+	for _, group := range cs {
+		result := GroupExplanation{Constraint: joinConstraintGroup(group), Satisfied: true}
+		for _, c := range group {
+			if c.check != nil && c.flag == StabilityStable && result.Note == "" {
+				result.Note = fmt.Sprintf("the @stable flag of %s is ignored by Check; it only sets the minimum stability", strings.TrimSpace(c.original))
+			}
+		}
+		for _, c := range group {
+			if c.Check(v) {
+				continue
+			}
+			result.Satisfied = false
+			result.Term = c
+			result.Reason, result.Message = c.explainMismatch(v)
+			break
+		}
+		if result.Satisfied {
+			explanation.Satisfied = true
+		}
+		explanation.Groups = append(explanation.Groups, result)
+	}
+	return explanation
+}
+
+// String renders the explanation as a short report, one line per OR group.
+func (e Explanation) String() string {
+	var b strings.Builder
+	if e.Satisfied {
+		fmt.Fprintf(&b, "%s satisfies the constraints", e.Version.Original())
+	} else {
+		fmt.Fprintf(&b, "%s does not satisfy the constraints", e.Version.Original())
+	}
+	for _, group := range e.Groups {
+		if group.Satisfied {
+			fmt.Fprintf(&b, "\n  %s: matched", group.Constraint)
+		} else {
+			fmt.Fprintf(&b, "\n  %s: %s", group.Constraint, group.Message)
+		}
+		if group.Note != "" {
+			fmt.Fprintf(&b, " (%s)", group.Note)
+		}
+	}
+	return b.String()
+}
+
+func joinConstraintGroup(group []*Constraint) string {
+	terms := make([]string, len(group))
+	for i, c := range group {
+		terms[i] = strings.TrimSpace(c.original)
+	}
+	return strings.Join(terms, ", ")
+}
+
+// explainMismatch classifies why c rejects v, following the order in which
+// Check tests them.
+func (c *Constraint) explainMismatch(v *Version) (Reason, string) {
+	term := strings.TrimSpace(c.original)
+	if c.stability != "" && stabilityLevels[strings.ToLower(c.stability)] > stabilityLevels[getVersionStability(v)] {
+		return ReasonStabilityFlag, fmt.Sprintf("stability flag @%s rejects %s version %s", expandStability(c.stability), expandStability(getVersionStability(v)), v.original)
+	}
+	if c.check == nil {
+		return ReasonNone, ""
+	}
+
+	if v.branch != "" || c.check.branch != "" {
+		switch c.operator {
+		case "", "=", "==":
+			return ReasonBranchMismatch, fmt.Sprintf("%s is not %s", v.original, c.check.original)
+		case "!=", "<>":
+			return ReasonExcluded, fmt.Sprintf("%s is excluded by %s", v.original, term)
+		default:
+			branch := v.original
+			if c.check.branch != "" {
+				branch = c.check.branch
+			}
+			return ReasonBranchOrdering, fmt.Sprintf("ordering operator %s never matches branch %s", c.operator, branch)
+		}
+	}
+
+	if c.check.scheme == SemVer2 {
+		return c.explainSemverMismatch(v, term)
+	}
+	if c.stableBound && c.excludesSameVersionPrerelease() && v.IsPrerelease() && equalInt64(v.segments, c.check.segments) {
+		return ReasonStableBound, fmt.Sprintf("%s is a prerelease of %s and %s excludes prereleases", v.original, c.check.Pretty(), term)
+	}
+
+	switch c.operator {
+	case "", "=", "==":
+		if !c.wildcard {
+			return ReasonExcluded, fmt.Sprintf("%s is not %s", v.original, term)
+		}
+	case "!=", "<>":
+		return ReasonExcluded, fmt.Sprintf("%s is excluded by %s", v.original, term)
+	}
+
+	if lower, upper, err := c.Bounds(); err == nil {
+		if cmp := v.Compare(lower.Version); cmp < 0 || (cmp == 0 && !lower.Inclusive) {
+			return ReasonBelowLowerBound, fmt.Sprintf("%s is below the lower bound %s of %s", v.original, formatBound(lower, ">"), term)
+		}
+		if !upper.Infinite {
+			if cmp := v.Compare(upper.Version); cmp > 0 || (cmp == 0 && !upper.Inclusive) {
+				return ReasonAboveUpperBound, fmt.Sprintf("%s is above the upper bound %s of %s", v.original, formatBound(upper, "<"), term)
+			}
+		}
+	}
+	if c.stableBound && v.IsPrerelease() && equalInt64(v.segments, c.check.segments) {
+		// "<2.0-stable" rejects 2.0 prereleases without a bound saying so.
+		return ReasonStableBound, fmt.Sprintf("%s is a prerelease of %s and %s excludes prereleases", v.original, c.check.Pretty(), term)
+	}
+	return ReasonOther, fmt.Sprintf("%s does not satisfy %s", v.original, term)
+}
+
+func (c *Constraint) explainSemverMismatch(v *Version, term string) (Reason, string) {
+	cmp := compareSemver(v, c.check)
+	switch {
+	case c.operator == "" || c.operator == "=" || c.operator == "==":
+		return ReasonExcluded, fmt.Sprintf("%s is not %s", v.original, term)
+	case c.operator == "!=" || c.operator == "<>":
+		return ReasonExcluded, fmt.Sprintf("%s is excluded by %s", v.original, term)
+	case c.operator == "<" || c.operator == "<=" || cmp > 0 || (cmp == 0 && c.operator != ">"):
+		return ReasonAboveUpperBound, fmt.Sprintf("%s is above the upper bound of %s", v.original, term)
+	default:
+		return ReasonBelowLowerBound, fmt.Sprintf("%s is below the lower bound of %s", v.original, term)
+	}
+}
+
+// formatBound renders bound with op, or op and "=" when it is inclusive.
+func formatBound(bound Bound, op string) string {
+	if bound.Inclusive {
+		op += "="
+	}
+	return op + bound.Version.NormalizedString()
+}
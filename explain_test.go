@@ -0,0 +1,138 @@
+package version
+
+import "testing"
+
+func TestExplain(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		reason     Reason
+		message    string
+	}{
+		{"^1.2", "1.0.0", ReasonBelowLowerBound, "1.0.0 is below the lower bound >=1.2.0.0-dev of ^1.2"},
+		{"^1.2", "2.0.0", ReasonAboveUpperBound, "2.0.0 is above the upper bound <2.0.0.0-dev of ^1.2"},
+		{"~1.2.3", "1.3.0", ReasonAboveUpperBound, "1.3.0 is above the upper bound <1.3.0.0-dev of ~1.2.3"},
+		{">1.0", "1.0.0", ReasonBelowLowerBound, "1.0.0 is below the lower bound >1.0.0.0 of >1.0"},
+		{"<=2.0", "2.0.1", ReasonAboveUpperBound, "2.0.1 is above the upper bound <=2.0.0.0 of <=2.0"},
+		{"1.2.*", "1.3.0", ReasonAboveUpperBound, "1.3.0 is above the upper bound <1.3.0.0-dev of 1.2.*"},
+		{"^1.2-stable", "1.2.0-beta1", ReasonStableBound, "1.2.0-beta1 is a prerelease of 1.2.0 and ^1.2-stable excludes prereleases"},
+		{"<2.0-stable", "2.0.0-beta1", ReasonStableBound, "2.0.0-beta1 is a prerelease of 2.0.0 and <2.0-stable excludes prereleases"},
+		{"@stable", "1.3.0-beta2", ReasonStabilityFlag, "stability flag @stable rejects beta version 1.3.0-beta2"},
+		{"@beta", "1.0.0-RC1", ReasonNone, ""},
+		{"1.2.3", "1.2.4", ReasonExcluded, "1.2.4 is not 1.2.3"},
+		{"!=1.5.0", "1.5.0", ReasonExcluded, "1.5.0 is excluded by !=1.5.0"},
+		{">dev-main", "dev-main", ReasonBranchOrdering, "ordering operator > never matches branch dev-main"},
+		{"^1.0", "dev-main", ReasonBranchOrdering, "ordering operator ^ never matches branch dev-main"},
+		{"dev-main", "dev-foo", ReasonBranchMismatch, "dev-foo is not dev-main"},
+		{"!=dev-main", "dev-main", ReasonExcluded, "dev-main is excluded by !=dev-main"},
+		{"^1.2@stable", "1.3.0-beta1", ReasonNone, ""},
+	}
+
+	for _, tc := range tests {
+		cs := MustConstraints(NewConstraint(tc.constraint))
+		v := Must(NewVersion(tc.version))
+		explanation := cs.Explain(v)
+		if explanation.Satisfied != cs.Check(v) {
+			t.Errorf("%q vs %s: Satisfied is %t but Check is %t", tc.constraint, tc.version, explanation.Satisfied, cs.Check(v))
+		}
+		group := explanation.Groups[0]
+		if group.Reason != tc.reason || group.Message != tc.message {
+			t.Errorf("%q vs %s: expected %s %q, got %s %q", tc.constraint, tc.version, tc.reason, tc.message, group.Reason, group.Message)
+		}
+		if group.Satisfied != (tc.reason == ReasonNone) {
+			t.Errorf("%q vs %s: expected group satisfied %t", tc.constraint, tc.version, tc.reason == ReasonNone)
+		}
+	}
+}
+
+func TestExplainStableFlagNote(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		note       string
+	}{
+		{"^1.2@stable", "1.3.0-beta1", "the @stable flag of ^1.2@stable is ignored by Check; it only sets the minimum stability"},
+		{">=1.0, <2.0@stable", "2.1.0", "the @stable flag of <2.0@stable is ignored by Check; it only sets the minimum stability"},
+		{"^1.2@beta", "1.3.0-beta1", ""},
+		{"@stable", "1.3.0", ""},
+	}
+
+	for _, tc := range tests {
+		cs := MustConstraints(NewConstraint(tc.constraint))
+		group := cs.Explain(Must(NewVersion(tc.version))).Groups[0]
+		if group.Note != tc.note {
+			t.Errorf("%q vs %s: expected note %q, got %q", tc.constraint, tc.version, tc.note, group.Note)
+		}
+	}
+
+	explanation := MustConstraints(NewConstraint("^1.2@stable")).Explain(Must(NewVersion("1.3.0-beta1")))
+	expected := `1.3.0-beta1 satisfies the constraints
+  ^1.2@stable: matched (the @stable flag of ^1.2@stable is ignored by Check; it only sets the minimum stability)`
+	if explanation.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, explanation.String())
+	}
+}
+
+func TestExplainGroups(t *testing.T) {
+	cs := MustConstraints(NewConstraint("^1.2, !=1.5.0 || dev-main || ^3.0"))
+	explanation := cs.Explain(Must(NewVersion("1.5.0")))
+	if explanation.Satisfied {
+		t.Fatal("expected 1.5.0 not to satisfy the constraints")
+	}
+	if len(explanation.Groups) != 3 {
+		t.Fatalf("expected 3 groups, got %d", len(explanation.Groups))
+	}
+	if term := explanation.Groups[0].Term; term == nil || term.String() != "!=1.5.0" {
+		t.Errorf("expected !=1.5.0 to reject the first group, got %v", term)
+	}
+
+	expected := `1.5.0 does not satisfy the constraints
+  ^1.2, !=1.5.0: 1.5.0 is excluded by !=1.5.0
+  dev-main: 1.5.0 is not dev-main
+  ^3.0: 1.5.0 is below the lower bound >=3.0.0.0-dev of ^3.0`
+	if explanation.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, explanation.String())
+	}
+
+	explanation = cs.Explain(Must(NewVersion("3.1.0")))
+	if !explanation.Satisfied || !explanation.Groups[2].Satisfied {
+		t.Errorf("expected 3.1.0 to satisfy the last group:\n%s", explanation)
+	}
+}
+
+func TestExplainMismatchFallback(t *testing.T) {
+	// Nothing classifies a version inside every bound of the term, so the
+	// reason is ReasonOther rather than a guess.
+	c := MustConstraints(NewConstraint("^1.2-stable"))[0][0]
+	reason, message := c.explainMismatch(Must(NewVersion("1.5.0")))
+	if reason != ReasonOther || message != "1.5.0 does not satisfy ^1.2-stable" {
+		t.Errorf("expected %s %q, got %s %q", ReasonOther, "1.5.0 does not satisfy ^1.2-stable", reason, message)
+	}
+	if ReasonOther.String() != "other" {
+		t.Errorf("unexpected name %q", ReasonOther.String())
+	}
+}
+
+func TestExplainSemver(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		reason     Reason
+	}{
+		{"^1.2.0", "1.1.9", ReasonBelowLowerBound},
+		{"^1.2.0", "2.0.0", ReasonAboveUpperBound},
+		{">1.0.0", "1.0.0", ReasonBelowLowerBound},
+		{"<1.0.0", "1.0.0", ReasonAboveUpperBound},
+		{"1.0.0", "1.0.1", ReasonExcluded},
+	}
+
+	for _, tc := range tests {
+		cs, err := NewConstraintWithOptions(tc.constraint, ParseOptions{Scheme: SemVer2})
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %s", tc.constraint, err)
+		}
+		if reason := cs.Explain(Must(NewSemver(tc.version))).Groups[0].Reason; reason != tc.reason {
+			t.Errorf("%q vs %s: expected %s, got %s", tc.constraint, tc.version, tc.reason, reason)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// This file holds the display helpers for Version: the fmt.Formatter
+// implementation and layout-based formatting for tags, badges and branch
+// names.
+
+// branchWildcardSegment is the value normalizeBranch substitutes for an "x"
+// in a numeric branch such as 2.1.x-dev.
+const branchWildcardSegment = 9999999
+
+// Format implements fmt.Formatter:
+//
+//	%s, %v  Original()
+//	%+s, %+v  NormalizedString()
+//	%q  Original() as a quoted string
+//	%#v  a Go expression that rebuilds the version
+//
+// Width and flags such as %-12s apply to the rendered string.
+func (v *Version) Format(f fmt.State, verb rune) {
+	if v == nil {
+		fmt.Fprint(f, "<nil>")
+		return
+	}
+
+	switch verb {
+	case 'v', 's':
+		if verb == 'v' && f.Flag('#') {
+			fmt.Fprintf(f, "version.Must(version.NewVersion(%q))", v.original)
+			return
+		}
+		s := v.original
+		if f.Flag('+') {
+			s = v.NormalizedString()
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), s)
+	case 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, 'q'), v.original)
+	default:
+		fmt.Fprintf(f, "%%!%c(*version.Version=%s)", verb, v.original)
+	}
+}
+
+// String returns NormalizedString(), or "<nil>" for an empty wrapper.
+func (v NormalizedVersion) String() string {
+	if v.Version == nil {
+		return "<nil>"
+	}
+	return v.NormalizedString()
+}
+
+// Format implements fmt.Formatter like Version.Format, except that %s, %v
+// and %q print NormalizedString(), the form the wrapper stands for.
+func (v NormalizedVersion) Format(f fmt.State, verb rune) {
+	if v.Version == nil {
+		fmt.Fprint(f, "<nil>")
+		return
+	}
+
+	switch verb {
+	case 'v', 's':
+		if verb == 'v' && f.Flag('#') {
+			fmt.Fprintf(f, "version.NormalizedVersion{Version: version.Must(version.NewVersion(%q))}", v.original)
+			return
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), v.NormalizedString())
+	case 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, 'q'), v.NormalizedString())
+	default:
+		fmt.Fprintf(f, "%%!%c(version.NormalizedVersion=%s)", verb, v.NormalizedString())
+	}
+}
+
+// FormatLayout renders v according to layout, replacing these placeholders:
+//
+//	{major} {minor} {patch} {build}  numeric segments
+//	{pre}         "-" and the prerelease, or nothing for stable versions
+//	{metadata}    "+" and the build metadata, or nothing
+//	{pretty}      the Pretty() form, e.g. 1.2.3 or 2.0.0-beta1
+//	{branch}      the BranchForm(), e.g. 2.1.x-dev
+//	{original}    Original()
+//	{normalized}  NormalizedString()
+//
+// Text outside placeholders, including unknown placeholders, is copied
+// verbatim, so "v{major}.{minor}" renders v1.2 for 1.2.3. Branch versions
+// (dev-*) have zero segments.
+func (v *Version) FormatLayout(layout string) string {
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(layout, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(layout[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		b.WriteString(layout[:start])
+		if value, ok := v.layoutPlaceholder(layout[start+1 : end]); ok {
+			b.WriteString(value)
+		} else {
+			b.WriteString(layout[start : end+1])
+		}
+		layout = layout[end+1:]
+	}
+	b.WriteString(layout)
+	return b.String()
+}
+
+func (v *Version) layoutPlaceholder(name string) (string, bool) {
+	switch name {
+	case "major":
+		return v.layoutSegment(0), true
+	case "minor":
+		return v.layoutSegment(1), true
+	case "patch":
+		return v.layoutSegment(2), true
+	case "build":
+		return v.layoutSegment(3), true
+	case "pre":
+		if v.branch != "" || v.pre == "" {
+			return "", true
+		}
+		return "-" + v.pre, true
+	case "metadata":
+		if v.metadata == "" {
+			return "", true
+		}
+		return "+" + v.metadata, true
+	case "pretty":
+		return v.Pretty(), true
+	case "branch":
+		return v.BranchForm(), true
+	case "original":
+		return v.original, true
+	case "normalized":
+		return v.NormalizedString(), true
+	default:
+		return "", false
+	}
+}
+
+func (v *Version) layoutSegment(i int) string {
+	if v.branch != "" || i >= len(v.segments) {
+		return "0"
+	}
+	return strconv.FormatInt(v.segments[i], 10)
+}
+
+// Pretty returns the version the way Composer displays it: at least three
+// segments, a fourth only when it is non-zero, wildcard segments of numeric
+// branches as "x", then the prerelease. 1.2.3 and 1.2.3.0 both render 1.2.3,
+// 2.1.x-dev stays 2.1.x-dev and branch versions render their name.
+func (v *Version) Pretty() string {
+	if v.branch != "" {
+		return v.branch
+	}
+
+	segments := v.segments
+	for len(segments) > 3 && segments[len(segments)-1] == 0 {
+		segments = segments[:len(segments)-1]
+	}
+	parts := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		if segment == branchWildcardSegment {
+			parts = append(parts, "x")
+			break
+		}
+		parts = append(parts, strconv.FormatInt(segment, 10))
+	}
+
+	pretty := strings.Join(parts, ".")
+	if v.pre != "" {
+		pretty += "-" + v.pre
+	}
+	return pretty
+}
+
+// BranchForm returns the numeric branch v belongs to, as used for branch
+// aliases: 2.1.3 and 2.1.0-beta1 both render 2.1.x-dev. Branch versions
+// render their name.
+func (v *Version) BranchForm() string {
+	if v.branch != "" {
+		return v.branch
+	}
+
+	parts := make([]string, 0, 2)
+	for _, segment := range v.segments[:2] {
+		if segment == branchWildcardSegment {
+			break
+		}
+		parts = append(parts, strconv.FormatInt(segment, 10))
+	}
+	return strings.Join(append(parts, "x-dev"), ".")
+}
@@ -0,0 +1,126 @@
+package version
+
+import (
+	"fmt"
+	"testing"
+)
+
+var (
+	_ fmt.Formatter = (*Version)(nil)
+	_ fmt.Formatter = NormalizedVersion{}
+	_ fmt.Stringer  = NormalizedVersion{}
+)
+
+func TestVersionFormatVerbs(t *testing.T) {
+	v := Must(NewVersion("v1.2-beta1"))
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"%s", "v1.2-beta1"},
+		{"%v", "v1.2-beta1"},
+		{"%+v", "1.2.0.0-beta1"},
+		{"%q", `"v1.2-beta1"`},
+		{"%#v", `version.Must(version.NewVersion("v1.2-beta1"))`},
+		{"[%-12s]", "[v1.2-beta1  ]"},
+		{"[%12s]", "[  v1.2-beta1]"},
+		{"%d", "%!d(*version.Version=v1.2-beta1)"},
+	}
+
+	for _, tc := range tests {
+		if actual := fmt.Sprintf(tc.format, v); actual != tc.expected {
+			t.Errorf("%s: expected %s, got %s", tc.format, tc.expected, actual)
+		}
+	}
+
+	var nilVersion *Version
+	if actual := fmt.Sprintf("%s", nilVersion); actual != "<nil>" {
+		t.Errorf("nil: expected <nil>, got %s", actual)
+	}
+}
+
+func TestNormalizedVersionFormatVerbs(t *testing.T) {
+	v := NormalizedVersion{Must(NewVersion("v1.2-beta1"))}
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"%s", "1.2.0.0-beta1"},
+		{"%v", "1.2.0.0-beta1"},
+		{"%+v", "1.2.0.0-beta1"},
+		{"%q", `"1.2.0.0-beta1"`},
+		{"%#v", `version.NormalizedVersion{Version: version.Must(version.NewVersion("v1.2-beta1"))}`},
+		{"[%-15s]", "[1.2.0.0-beta1  ]"},
+		{"%d", "%!d(version.NormalizedVersion=1.2.0.0-beta1)"},
+	}
+
+	for _, tc := range tests {
+		if actual := fmt.Sprintf(tc.format, v); actual != tc.expected {
+			t.Errorf("%s: expected %s, got %s", tc.format, tc.expected, actual)
+		}
+		if actual := fmt.Sprintf(tc.format, &v); actual != tc.expected {
+			t.Errorf("%s of a pointer: expected %s, got %s", tc.format, tc.expected, actual)
+		}
+	}
+
+	if actual := v.String(); actual != "1.2.0.0-beta1" {
+		t.Errorf("String(): expected 1.2.0.0-beta1, got %s", actual)
+	}
+	if actual := fmt.Sprintf("%s", NormalizedVersion{}); actual != "<nil>" {
+		t.Errorf("empty: expected <nil>, got %s", actual)
+	}
+}
+
+func TestVersionFormatLayout(t *testing.T) {
+	tests := []struct {
+		version  string
+		layout   string
+		expected string
+	}{
+		{"1.2.3", "{major}.{minor}", "1.2"},
+		{"1.2.3", "v{major}.{minor}.{patch}", "v1.2.3"},
+		{"1.2.3.4", "{major}.{minor}.{patch}.{build}", "1.2.3.4"},
+		{"2.0.0-beta2", "{major}.{minor}.{patch}{pre}", "2.0.0-beta2"},
+		{"2.0.0", "{major}.{minor}.{patch}{pre}", "2.0.0"},
+		{"1.0.0+build.7", "{pretty}{metadata}", "1.0.0+build.7"},
+		{"1.2.3", "release/{branch}", "release/1.2.x-dev"},
+		{"v1.2", "{original} -> {normalized}", "v1.2 -> 1.2.0.0"},
+		{"1.2.3", "{major}-{unknown}-{minor", "1-{unknown}-{minor"},
+		{"dev-main", "{major}.{minor}:{branch}", "0.0:dev-main"},
+	}
+
+	for _, tc := range tests {
+		if actual := Must(NewVersion(tc.version)).FormatLayout(tc.layout); actual != tc.expected {
+			t.Errorf("%s with %q: expected %s, got %s", tc.version, tc.layout, tc.expected, actual)
+		}
+	}
+}
+
+func TestVersionPrettyAndBranchForm(t *testing.T) {
+	tests := []struct {
+		version string
+		pretty  string
+		branch  string
+	}{
+		{"1.2.3", "1.2.3", "1.2.x-dev"},
+		{"1.2.3.0", "1.2.3", "1.2.x-dev"},
+		{"1.2", "1.2.0", "1.2.x-dev"},
+		{"1.2.3.4", "1.2.3.4", "1.2.x-dev"},
+		{"v2.0.0-b2", "2.0.0-beta2", "2.0.x-dev"},
+		{"1.0.0-p1", "1.0.0-patch1", "1.0.x-dev"},
+		{"2.1.x-dev", "2.1.x-dev", "2.1.x-dev"},
+		{"3.x-dev", "3.x-dev", "3.x-dev"},
+		{"dev-feature/x", "dev-feature/x", "dev-feature/x"},
+		{"master", "dev-master", "dev-master"},
+	}
+
+	for _, tc := range tests {
+		v := Must(NewVersion(tc.version))
+		if actual := v.Pretty(); actual != tc.pretty {
+			t.Errorf("%s: expected pretty %s, got %s", tc.version, tc.pretty, actual)
+		}
+		if actual := v.BranchForm(); actual != tc.branch {
+			t.Errorf("%s: expected branch form %s, got %s", tc.version, tc.branch, actual)
+		}
+	}
+}
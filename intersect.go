@@ -1,6 +1,7 @@
 package version
 
 import (
+	"fmt"
 	"strings"
 )
 
@@ -57,6 +58,96 @@ func ConstraintSubsetOf(left, right string) (bool, error) {
 	return true, nil
 }
 
+// ConstraintEquivalent reports whether left and right match exactly the same
+// versions, as "~1.2" and "^1.2" or "1.0 - 2.0" and ">=1.0 <2.1" do.
+//
+// A bare stability filter such as "@beta" limits its group to versions at
+// least that stable, so "*" and "@stable" differ on every prerelease. When
+// the groups that apply to some stability match different versions, which
+// may all be less stable than that, ConstraintEquivalent looks for a version
+// the constraints disagree on, and returns an error if it cannot find one.
+func ConstraintEquivalent(left, right string) (bool, error) {
+	leftConstraints, err := NewConstraint(normalizeConstraintInput(left))
+	if err != nil {
+		return false, err
+	}
+	rightConstraints, err := NewConstraint(normalizeConstraintInput(right))
+	if err != nil {
+		return false, err
+	}
+
+	for level := range len(stabilityLevels) {
+		leftDomains, err := leftConstraints.domainsAtStability(level)
+		if err != nil {
+			return false, err
+		}
+		rightDomains, err := rightConstraints.domainsAtStability(level)
+		if err != nil {
+			return false, err
+		}
+		if domainUnionsEquivalent(leftDomains, rightDomains) {
+			continue
+		}
+		if !leftConstraints.hasStabilityFilter() && !rightConstraints.hasStabilityFilter() {
+			return false, nil
+		}
+		return differByProbe(leftConstraints, rightConstraints, left, right)
+	}
+	return true, nil
+}
+
+// domainsAtStability returns the domains of the OR groups of cs that apply
+// to versions of the given stability level: those without a bare stability
+// filter asking for more.
+func (cs Constraints) domainsAtStability(level int) ([]constraintDomain, error) {
+	var domains []constraintDomain
+	for _, group := range cs {
+		if groupStabilityLevel(group) > level {
+			continue
+		}
+		domain, err := constraintsDomain(group)
+		if err != nil {
+			return nil, err
+		}
+		domains = append(domains, domain)
+	}
+	return domains, nil
+}
+
+// hasStabilityFilter reports whether any group of cs has a bare stability
+// filter.
+func (cs Constraints) hasStabilityFilter() bool {
+	for _, group := range cs {
+		if groupStabilityLevel(group) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// groupStabilityLevel returns the level of the strictest bare stability
+// filter in group, or 0 when it has none.
+func groupStabilityLevel(group []*Constraint) int {
+	level := 0
+	for _, c := range group {
+		if c.stability != "" {
+			level = max(level, stabilityLevels[strings.ToLower(c.stability)])
+		}
+	}
+	return level
+}
+
+// differByProbe reports false when some version of checkProbes tells left
+// and right apart, and an error when none does.
+func differByProbe(left, right Constraints, leftRaw, rightRaw string) (bool, error) {
+	for _, v := range checkProbes(left, right) {
+		if left.Check(v) != right.Check(v) {
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("cannot tell whether %q and %q match the same versions", leftRaw, rightRaw)
+}
+
 func normalizeConstraintInput(constraint string) string {
 	constraint = strings.TrimSpace(constraint)
 	if constraint == "" {
@@ -70,9 +161,13 @@ func constraintUnionDomains(constraint string) ([]constraintDomain, error) {
 	if err != nil {
 		return nil, err
 	}
+	return parsed.domains()
+}
 
-	domains := make([]constraintDomain, 0, len(parsed))
-	for _, andConstraints := range parsed {
+// domains returns the domain of each OR group of cs.
+func (cs Constraints) domains() ([]constraintDomain, error) {
+	domains := make([]constraintDomain, 0, len(cs))
+	for _, andConstraints := range cs {
 		domain, err := constraintsDomain(andConstraints)
 		if err != nil {
 			return nil, err
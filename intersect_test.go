@@ -142,6 +142,120 @@ func TestConstraintSubsetOf(t *testing.T) {
 	}
 }
 
+func TestMatchNoneConstraint(t *testing.T) {
+	for _, constraint := range []string{"<0.0.0.0-dev", "<0.0", "<0.*"} {
+		intersects, err := ConstraintIntersects(constraint, "*")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if intersects {
+			t.Errorf("ConstraintIntersects(%q, \"*\"): expected false", constraint)
+		}
+		for _, other := range []string{">2.0", "dev-main", ">2.0 <1.0"} {
+			subset, err := ConstraintSubsetOf(constraint, other)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !subset {
+				t.Errorf("ConstraintSubsetOf(%q, %q): expected true", constraint, other)
+			}
+		}
+	}
+}
+
+func TestWildcardExclusionDomain(t *testing.T) {
+	intersects := []struct {
+		left     string
+		right    string
+		expected bool
+	}{
+		{"!=1.*", "1.5", false},
+		{"!=1.*", "^1.2", false},
+		{"!=1.*", "2.0", true},
+		{"!=1.*", "dev-main", true},
+		{"!=0.*", "<1.0", false},
+		{"!=1.2.*", "~1.2.3", false},
+		{"!=1.2.*", "^1.2", true},
+	}
+	for _, tc := range intersects {
+		actual, err := ConstraintIntersects(tc.left, tc.right)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual != tc.expected {
+			t.Errorf("ConstraintIntersects(%q, %q): expected %v, got %v", tc.left, tc.right, tc.expected, actual)
+		}
+	}
+
+	subsets := []struct {
+		left     string
+		right    string
+		expected bool
+	}{
+		{"^2.0 || <1.0 || dev-main", "!=1.*", true},
+		{"^1.5", "!=1.*", false},
+		{"!=1.*", ">=2.0", false},
+		{"!=1.*, !=2.*", "!=1.*", true},
+	}
+	for _, tc := range subsets {
+		actual, err := ConstraintSubsetOf(tc.left, tc.right)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual != tc.expected {
+			t.Errorf("ConstraintSubsetOf(%q, %q): expected %v, got %v", tc.left, tc.right, tc.expected, actual)
+		}
+	}
+}
+
+func TestConstraintEquivalent(t *testing.T) {
+	tests := []struct {
+		left     string
+		right    string
+		expected bool
+	}{
+		{"~1.2", "^1.2", true},
+		{"1.0 - 2.0", ">=1.0 <2.1", true},
+		{"1.0.0 - 2.0.0", ">=1.0 <=2.0", true},
+		{"^1.0 || ^1.5", "^1.0", true},
+		{"1.2.*", ">=1.2 <1.3", true},
+		{"", "*", true},
+		{"!=dev-foo, !=dev-bar", "!=dev-bar, !=dev-foo", true},
+		{">2.0 <1.0", "<0.0.0.0-dev", true},
+		{"^1.2", "^1.0", false},
+		{"~1.2.3", "^1.2.3", false},
+		{">=1.0", ">=1.0-stable", false},
+		{"dev-main", "dev-master", false},
+		{"*", "@stable", false},
+		{"*", "@beta", false},
+		{"@beta", "@stable", false},
+		{"@stable", "@STABLE", true},
+		{"^1.0@beta", "^1.0@stable", false},
+		{"^1.0, @beta", ">=1.0 <2.0-dev, @beta", true},
+		{"@beta || ^1.0", "@beta || >=1.0-dev <1.0", false},
+	}
+
+	for _, tc := range tests {
+		actual, err := ConstraintEquivalent(tc.left, tc.right)
+		if err != nil {
+			t.Errorf("ConstraintEquivalent(%q, %q) unexpected error: %v", tc.left, tc.right, err)
+			continue
+		}
+		if actual != tc.expected {
+			t.Errorf("ConstraintEquivalent(%q, %q): expected %v, got %v", tc.left, tc.right, tc.expected, actual)
+		}
+	}
+
+	if _, err := ConstraintEquivalent("^1.0", ">>1.0"); err == nil {
+		t.Error("expected error for malformed constraint")
+	}
+	// The groups differ only on 1.0 dev and alpha versions, which "@beta"
+	// excludes from both.
+	if _, err := ConstraintEquivalent("^1.0, @beta", ">=1.0-beta <2.0-dev, @beta"); err == nil {
+		t.Error("expected error for domains that differ only below the stability filter")
+	}
+}
+
 func TestComposeConstraintDomains(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -0,0 +1,83 @@
+package version
+
+// This file holds the public view of a constraint's version set, the
+// counterpart of Composer's Intervals::get().
+
+// IntervalSet is the exact set of versions a constraint matches: numeric
+// versions inside any of the Numeric intervals, plus the branches (dev-*)
+// described by Branches.
+type IntervalSet struct {
+	Numeric  []Interval `json:"numeric"`
+	Branches BranchSet  `json:"branches"`
+}
+
+// Interval is a range of numeric versions with optional excluded points,
+// such as the 1.5.0.0 of "^1.2, !=1.5.0".
+type Interval struct {
+	Lower      Bound      `json:"lower"`
+	Upper      Bound      `json:"upper"`
+	Exclusions []*Version `json:"exclusions,omitempty"`
+}
+
+// BranchSet describes the branch versions a constraint matches. With
+// Exclude unset only the listed Names match; with Exclude set every branch
+// except the listed Names matches.
+type BranchSet struct {
+	Names   []string `json:"names"`
+	Exclude bool     `json:"exclude"`
+}
+
+// Intervals parses constraint and returns the set of versions it matches.
+// Numeric intervals are sorted and merged, so "^1.2 || ^1.5 || ^3.0" yields
+// two intervals. An empty constraint matches everything.
+func Intervals(constraint string) (IntervalSet, error) {
+	parsed, err := NewConstraint(normalizeConstraintInput(constraint))
+	if err != nil {
+		return IntervalSet{}, err
+	}
+	return parsed.Intervals()
+}
+
+// Intervals returns the set of versions cs matches; see the package-level
+// Intervals.
+func (cs Constraints) Intervals() (IntervalSet, error) {
+	domains, err := cs.domains()
+	if err != nil {
+		return IntervalSet{}, err
+	}
+
+	set := IntervalSet{Numeric: []Interval{}}
+	for _, interval := range joinExcludedPoints(mergeNumericIntervals(unionNumericIntervals(domains))) {
+		public := Interval{
+			Lower: publicLowerBound(interval.lower),
+			Upper: publicUpperBound(interval.upper),
+		}
+		for _, excluded := range interval.exclusions {
+			public.Exclusions = append(public.Exclusions, publicBoundVersion(excluded))
+		}
+		set.Numeric = append(set.Numeric, public)
+	}
+
+	branches := snapshotBranches(domains)
+	set.Branches = BranchSet{Names: branches.Names, Exclude: branches.Exclude}
+	return set, nil
+}
+
+// joinExcludedPoints rejoins sorted, disjoint intervals that are only split
+// by a single excluded version, recording that version as an exclusion.
+func joinExcludedPoints(intervals []versionInterval) []versionInterval {
+	var joined []versionInterval
+	for _, interval := range intervals {
+		if len(joined) > 0 {
+			last := &joined[len(joined)-1]
+			if last.upper != nil && interval.lower != nil && !last.upper.inclusive && !interval.lower.inclusive &&
+				last.upper.version.Equal(interval.lower.version) {
+				last.exclusions = append(last.exclusions, interval.lower.version)
+				last.upper = interval.upper
+				continue
+			}
+		}
+		joined = append(joined, interval)
+	}
+	return joined
+}
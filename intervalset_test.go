@@ -0,0 +1,100 @@
+package version
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestIntervals(t *testing.T) {
+	tests := []struct {
+		constraint string
+		expected   string
+	}{
+		{
+			"^1.2",
+			`{"numeric":[{"lower":{"version":"1.2.0.0-dev","inclusive":true},"upper":{"version":"2.0.0.0-dev","inclusive":false}}],"branches":{"names":[],"exclude":false}}`,
+		},
+		{
+			"^1.2 || ^1.5 || ^3.0",
+			`{"numeric":[{"lower":{"version":"1.2.0.0-dev","inclusive":true},"upper":{"version":"2.0.0.0-dev","inclusive":false}},{"lower":{"version":"3.0.0.0-dev","inclusive":true},"upper":{"version":"4.0.0.0-dev","inclusive":false}}],"branches":{"names":[],"exclude":false}}`,
+		},
+		{
+			"^1.2, !=1.5.0, !=1.6.0",
+			`{"numeric":[{"lower":{"version":"1.2.0.0-dev","inclusive":true},"upper":{"version":"2.0.0.0-dev","inclusive":false},"exclusions":["1.5.0.0","1.6.0.0"]}],"branches":{"names":[],"exclude":false}}`,
+		},
+		{
+			"!=dev-main",
+			`{"numeric":[{"lower":{"version":"0.0.0.0-dev","inclusive":true},"upper":{"inclusive":false,"infinite":true}}],"branches":{"names":["dev-main"],"exclude":true}}`,
+		},
+		{
+			"dev-main || dev-next || >=2.0",
+			`{"numeric":[{"lower":{"version":"2.0.0.0-dev","inclusive":true},"upper":{"inclusive":false,"infinite":true}}],"branches":{"names":["dev-main","dev-next"],"exclude":false}}`,
+		},
+		{
+			">2.0 <1.0",
+			`{"numeric":[],"branches":{"names":[],"exclude":false}}`,
+		},
+	}
+
+	for _, tc := range tests {
+		set, err := Intervals(tc.constraint)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", tc.constraint, err)
+			continue
+		}
+		data, err := json.Marshal(set)
+		if err != nil {
+			t.Errorf("%q: marshal error: %s", tc.constraint, err)
+			continue
+		}
+		if string(data) != tc.expected {
+			t.Errorf("%q:\nexpected %s\ngot      %s", tc.constraint, tc.expected, data)
+		}
+	}
+}
+
+func TestIntervalsMatchCheck(t *testing.T) {
+	constraints := []string{"^1.2, !=1.5.0", "~1.2.3 || 2.*", ">=1.0 <1.1 || >1.1 <2.0", "1.0 - 1.4, !=1.2.0"}
+	versions := []string{"1.0.0", "1.1.0", "1.2.0", "1.2.3", "1.2.9", "1.3.0", "1.5.0", "1.5.1", "2.0.0", "2.4.0", "3.0.0"}
+
+	for _, constraint := range constraints {
+		cs := MustConstraints(NewConstraint(constraint))
+		set, err := cs.Intervals()
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %s", constraint, err)
+		}
+		for _, raw := range versions {
+			v := Must(NewVersion(raw))
+			if inSet := set.containsNumeric(v); inSet != cs.Check(v) {
+				t.Errorf("%q vs %s: intervals say %t, Check says %t", constraint, raw, inSet, cs.Check(v))
+			}
+		}
+	}
+
+	if _, err := Intervals(">>1.0"); err == nil {
+		t.Error("expected error for malformed constraint")
+	}
+}
+
+func (s IntervalSet) containsNumeric(v *Version) bool {
+	for _, interval := range s.Numeric {
+		lower := v.Compare(interval.Lower.Version)
+		if lower < 0 || (lower == 0 && !interval.Lower.Inclusive) {
+			continue
+		}
+		if !interval.Upper.Infinite {
+			upper := v.Compare(interval.Upper.Version)
+			if upper > 0 || (upper == 0 && !interval.Upper.Inclusive) {
+				continue
+			}
+		}
+		excluded := false
+		for _, exclusion := range interval.Exclusions {
+			excluded = excluded || v.Equal(exclusion)
+		}
+		if !excluded {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+//go:build go1.27 && goexperiment.jsonv2
+
+package version
+
+import (
+	"encoding/json"
+	"encoding/json/jsontext"
+	jsonv2 "encoding/json/v2"
+	"errors"
+	"reflect"
+)
+
+// jsonParseError reports a JSON string that failed to parse as typ. It is a
+// *json.UnmarshalTypeError that wraps the parse error.
+func jsonParseError(typ reflect.Type, err error) error {
+	return &json.UnmarshalTypeError{Value: "string", Type: typ, Err: err}
+}
+
+// UnmarshalJSONFrom implements json/v2.UnmarshalerFrom. It decodes like
+// UnmarshalJSON, but its errors carry the position of the value, so
+// encoding/json reports the struct field that held it.
+func (v *Version) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, v.UnmarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json/v2.UnmarshalerFrom, like
+// Version.UnmarshalJSONFrom.
+func (v *NormalizedVersion) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, v.UnmarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json/v2.UnmarshalerFrom, like
+// Version.UnmarshalJSONFrom.
+func (cs *Constraints) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, cs.UnmarshalJSON)
+}
+
+// unmarshalJSONFrom reads the next value from dec and hands it to unmarshal.
+// The json/v2 decoder returns method errors verbatim, so a type error is
+// turned into a *json/v2.SemanticError at the value's JSON pointer, which
+// encoding/json then reports as a *json.UnmarshalTypeError naming the field.
+func unmarshalJSONFrom(dec *jsontext.Decoder, unmarshal func([]byte) error) error {
+	val, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+	err = unmarshal(val)
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) {
+		return err
+	}
+
+	semErr := &jsonv2.SemanticError{
+		ByteOffset:  dec.InputOffset() - int64(len(val)),
+		JSONPointer: dec.StackPointer(),
+		JSONKind:    val.Kind(),
+		GoType:      typeErr.Type,
+		Err:         typeErr.Err,
+	}
+	if val.Kind() == '0' {
+		semErr.JSONValue = val
+	}
+	return semErr
+}
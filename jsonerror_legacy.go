@@ -0,0 +1,15 @@
+//go:build !go1.27 || !goexperiment.jsonv2
+
+package version
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// jsonParseError reports a JSON string that failed to parse as typ. Without
+// the json/v2 backend a *json.UnmarshalTypeError cannot wrap the parse error,
+// but it is the only error encoding/json adds the field to.
+func jsonParseError(typ reflect.Type, err error) error {
+	return &json.UnmarshalTypeError{Value: "string", Type: typ}
+}
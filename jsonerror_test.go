@@ -0,0 +1,49 @@
+//go:build go1.27 && goexperiment.jsonv2
+
+package version
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestJSONDecodeErrorWrapsParseError(t *testing.T) {
+	var target struct {
+		Package struct {
+			Version *Version `json:"version"`
+		} `json:"package"`
+		Version    *Version          `json:"version"`
+		Normalized NormalizedVersion `json:"normalized"`
+		Require    Constraints       `json:"require"`
+	}
+
+	tests := []struct {
+		input  string
+		kind   error
+		token  string
+		offset int
+	}{
+		{`{"version":"not a version"}`, ErrMalformedVersion, "not a version", 0},
+		{`{"package":{"version":"not a version"}}`, ErrMalformedVersion, "not a version", 0},
+		{`{"normalized":"1.0.0-foo"}`, ErrMalformedVersion, "1.0.0-foo", 0},
+		{`{"require":"^1.0 || >>2.0"}`, ErrUnknownOperator, ">>", 8},
+		{`{"require":"^1.0@foo"}`, ErrInvalidStability, "foo", 5},
+	}
+
+	for _, tc := range tests {
+		err := json.Unmarshal([]byte(tc.input), &target)
+		if !errors.Is(err, tc.kind) {
+			t.Errorf("Unmarshal(%s): expected errors.Is %v, got %v", tc.input, tc.kind, err)
+			continue
+		}
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("Unmarshal(%s): expected *ParseError, got %T", tc.input, err)
+			continue
+		}
+		if parseErr.Token != tc.token || parseErr.Offset != tc.offset {
+			t.Errorf("Unmarshal(%s): expected token %q at %d, got %q at %d", tc.input, tc.token, tc.offset, parseErr.Token, parseErr.Offset)
+		}
+	}
+}
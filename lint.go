@@ -0,0 +1,490 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// This file holds Lint, which reports constraints that parse but are likely
+// mistakes, in the spirit of `composer validate`.
+
+// Severity ranks a Diagnostic.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+// String returns "info", "warning" or "error".
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return "Severity(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+// Diagnostic codes reported by Lint.
+const (
+	LintParseError           = "parse-error"
+	LintUnbounded            = "unbounded"
+	LintExactPin             = "exact-pin"
+	LintSinglePipe           = "single-pipe"
+	LintEmptyGroup           = "empty-group"
+	LintUnreachableGroup     = "unreachable-group"
+	LintOverlappingGroups    = "overlapping-groups"
+	LintConflictingStability = "conflicting-stability"
+	LintTypo                 = "typo"
+)
+
+// Diagnostic is a single finding of Lint. Start and End are the byte span
+// of the offending text in the linted constraint. Fix is the whole
+// constraint rewritten to address the finding, or "" when there is no
+// mechanical fix. The fixes for LintExactPin and LintUnbounded change the
+// range as their message suggests; every other Fix matches exactly the
+// versions the linted constraint matches.
+type Diagnostic struct {
+	Severity Severity
+	Code     string
+	Message  string
+	Start    int
+	End      int
+	Fix      string
+}
+
+// Lint checks constraint for problems Composer accepts silently:
+//
+//   - unbounded constraints such as ">=1.0" or "*"
+//   - exact pins such as "1.2.3"
+//   - the deprecated single "|" separator
+//   - OR groups that can never match, or are covered by other groups
+//   - OR groups that overlap and can be merged
+//   - @stability flags that another flag in the constraint overrides
+//   - version typos such as "1.0..dev" that parsing corrects
+//
+// A constraint that does not parse yields a single SeverityError diagnostic
+// spanning the offending token. Diagnostics are sorted by Start.
+func Lint(constraint string) []Diagnostic {
+	cs, err := NewConstraint(constraint)
+	if err != nil {
+		return []Diagnostic{parseErrorDiagnostic(constraint, err)}
+	}
+
+	groups, err := lintGroups(constraint, cs)
+	if err != nil {
+		return []Diagnostic{parseErrorDiagnostic(constraint, err)}
+	}
+	domains := make([]constraintDomain, len(groups))
+	for i, group := range groups {
+		domains[i] = group.domain
+	}
+
+	var diagnostics []Diagnostic
+	diagnostics = append(diagnostics, lintSinglePipes(constraint)...)
+	for _, group := range groups {
+		for _, term := range group.terms {
+			diagnostics = append(diagnostics, lintTerm(constraint, term)...)
+		}
+	}
+	diagnostics = append(diagnostics, lintGroupDomains(constraint, groups)...)
+	diagnostics = append(diagnostics, lintStabilityFlags(constraint, groups)...)
+	for i, d := range diagnostics {
+		if d.Fix != "" && !validFix(cs, domains, d) {
+			diagnostics[i].Fix = ""
+		}
+	}
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].Start < diagnostics[j].Start
+	})
+	return diagnostics
+}
+
+// validFix reports whether the Fix of d parses and, unless d is meant to
+// change the range, matches the same versions as cs, whose group domains are
+// given.
+func validFix(cs Constraints, domains []constraintDomain, d Diagnostic) bool {
+	switch d.Code {
+	case LintExactPin, LintUnbounded:
+		_, err := NewConstraint(d.Fix)
+		return err == nil
+	default:
+		return sameVersions(cs, domains, d.Fix)
+	}
+}
+
+// lintGroup is an OR group of the linted constraint with the span of its
+// text and of each of its terms.
+type lintGroup struct {
+	start, end int
+	terms      []lintTermSpan
+	domain     constraintDomain
+}
+
+// lintTermSpan is a single term as written, such as ">= 1.0" or a whole
+// hyphen range, and the constraints it parsed to. aliased is set for the
+// terms of a group followed by an inline alias.
+type lintTermSpan struct {
+	text        string
+	start, end  int
+	constraints []*Constraint
+	aliased     bool
+}
+
+func parseErrorDiagnostic(constraint string, err error) Diagnostic {
+	d := Diagnostic{Severity: SeverityError, Code: LintParseError, Message: err.Error(), End: len(constraint)}
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) {
+		d.Start = parseErr.Offset
+		d.End = parseErr.Offset + len(parseErr.Token)
+	}
+	return d
+}
+
+func lintSinglePipes(constraint string) []Diagnostic {
+	var diagnostics []Diagnostic
+	fix := ""
+	for i := 0; i < len(constraint); i++ {
+		if constraint[i] != '|' {
+			continue
+		}
+		if i+1 < len(constraint) && constraint[i+1] == '|' {
+			i++
+			continue
+		}
+		if fix == "" {
+			fix = replaceSinglePipes(constraint)
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Severity: SeverityWarning,
+			Code:     LintSinglePipe,
+			Message:  `the single "|" separator is deprecated, use "||"`,
+			Start:    i,
+			End:      i + 1,
+			Fix:      fix,
+		})
+	}
+	return diagnostics
+}
+
+func replaceSinglePipes(constraint string) string {
+	var b strings.Builder
+	for i := 0; i < len(constraint); i++ {
+		if constraint[i] == '|' {
+			b.WriteString("||")
+			if i+1 < len(constraint) && constraint[i+1] == '|' {
+				i++
+			}
+			continue
+		}
+		b.WriteByte(constraint[i])
+	}
+	return b.String()
+}
+
+// lintGroups splits constraint the way NewConstraint does, keeping the byte
+// span of every OR group and term.
+func lintGroups(constraint string, cs Constraints) ([]lintGroup, error) {
+	ors, offsets := splitOrConstraints(constraint)
+	groups := make([]lintGroup, 0, len(ors))
+	for k, or := range ors {
+		text := stripConstraintAlias(or)
+		trimmed := strings.TrimSpace(text)
+		start := offsets[k] + strings.Index(or, trimmed)
+		group := lintGroup{start: start, end: start + len(trimmed)}
+
+		if strings.Contains(text, " - ") && !strings.Contains(text, ",") {
+			group.terms = []lintTermSpan{{text: trimmed, start: group.start, end: group.end}}
+		} else {
+			group.terms = splitLintTerms(trimmed, group.start)
+		}
+		for i := range group.terms {
+			group.terms[i].constraints, _ = parseOrConstraint(group.terms[i].text, Composer)
+			group.terms[i].aliased = text != or
+		}
+		domain, err := constraintsDomain(cs[k])
+		if err != nil {
+			return nil, err
+		}
+		group.domain = domain
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// splitLintTerms mirrors splitAndConstraints: terms are separated by commas
+// or whitespace, and an operator written apart from its version belongs to
+// the next field.
+func splitLintTerms(group string, base int) []lintTermSpan {
+	var terms []lintTermSpan
+	offset := 0
+	for _, part := range strings.Split(group, ",") {
+		partStart := base + offset
+		offset += len(part) + 1
+		if strings.Contains(part, " - ") {
+			trimmed := strings.TrimSpace(part)
+			start := partStart + strings.Index(part, trimmed)
+			terms = append(terms, lintTermSpan{text: trimmed, start: start, end: start + len(trimmed)})
+			continue
+		}
+
+		var pending *lintTermSpan
+		for i := 0; i < len(part); {
+			if part[i] == ' ' || part[i] == '\t' || part[i] == '\n' || part[i] == '\r' {
+				i++
+				continue
+			}
+			end := i
+			for end < len(part) && part[end] != ' ' && part[end] != '\t' && part[end] != '\n' && part[end] != '\r' {
+				end++
+			}
+			field := part[i:end]
+			switch {
+			case pending != nil:
+				pending.text += field
+				pending.end = partStart + end
+				terms = append(terms, *pending)
+				pending = nil
+			case isOperator(field):
+				pending = &lintTermSpan{text: field, start: partStart + i, end: partStart + end}
+			default:
+				terms = append(terms, lintTermSpan{text: field, start: partStart + i, end: partStart + end})
+			}
+			i = end
+		}
+		if pending != nil {
+			terms = append(terms, *pending)
+		}
+	}
+	return terms
+}
+
+func lintTerm(constraint string, term lintTermSpan) []Diagnostic {
+	typos := lintTypos(constraint, term)
+	if len(term.constraints) != 1 || term.aliased {
+		// An inline alias such as "1.0.0 as 2.0.0" is meant to be exact.
+		return typos
+	}
+	c := term.constraints[0]
+	if c.check == nil || c.check.branch != "" || c.wildcard || hasWildcardSegment(c.check) ||
+		(c.operator != "" && c.operator != "=" && c.operator != "==") {
+		return typos
+	}
+
+	caret := "^" + c.check.Pretty()
+	pin := Diagnostic{
+		Severity: SeverityWarning,
+		Code:     LintExactPin,
+		Message:  fmt.Sprintf("%s pins an exact version and blocks bug fixes, consider %s", term.text, caret),
+		Start:    term.start,
+		End:      term.end,
+		Fix:      replaceSpan(constraint, term.start, term.end, caret),
+	}
+	return append([]Diagnostic{pin}, typos...)
+}
+
+// lintTypos reports misspellings in term that normalizeConstraintVersionTypos
+// silently corrects.
+func lintTypos(constraint string, term lintTermSpan) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, typo := range constraintVersionTypos {
+		for offset := 0; ; {
+			index := strings.Index(term.text[offset:], typo.typo)
+			if index < 0 {
+				break
+			}
+			start := term.start + offset + index
+			diagnostics = append(diagnostics, Diagnostic{
+				Severity: SeverityWarning,
+				Code:     LintTypo,
+				Message:  fmt.Sprintf("%q is read as %q", typo.typo, typo.fix),
+				Start:    start,
+				End:      start + len(typo.typo),
+				Fix:      replaceSpan(constraint, start, start+len(typo.typo), typo.fix),
+			})
+			offset += index + len(typo.typo)
+		}
+	}
+	version := term.text
+	if at := strings.LastIndex(version, "@"); at >= 0 {
+		version = version[:at]
+	}
+	if trimmed := strings.TrimRight(version, "."); trimmed != version && !strings.Contains(term.text, " - ") {
+		start := term.start + len(trimmed)
+		end := term.start + len(version)
+		diagnostics = append(diagnostics, Diagnostic{
+			Severity: SeverityWarning,
+			Code:     LintTypo,
+			Message:  fmt.Sprintf("trailing %q in %s is ignored", version[len(trimmed):], term.text),
+			Start:    start,
+			End:      end,
+			Fix:      replaceSpan(constraint, start, end, ""),
+		})
+	}
+	return diagnostics
+}
+
+// lintGroupDomains reports OR groups that match nothing, are covered by the
+// other groups, overlap another group or have no upper bound.
+func lintGroupDomains(constraint string, groups []lintGroup) []Diagnostic {
+	var diagnostics []Diagnostic
+	dropped := make([]bool, len(groups))
+	for i, group := range groups {
+		if domainEmpty(group.domain) {
+			dropped[i] = true
+			diagnostics = append(diagnostics, Diagnostic{
+				Severity: SeverityError,
+				Code:     LintEmptyGroup,
+				Message:  fmt.Sprintf("%s can never match: its terms have no version in common", constraint[group.start:group.end]),
+				Start:    group.start,
+				End:      group.end,
+				Fix:      withoutGroup(constraint, groups, i),
+			})
+		}
+	}
+
+	// Walk backwards so that of two equal groups the later one is reported.
+	for i := len(groups) - 1; i >= 0; i-- {
+		group := groups[i]
+		if dropped[i] {
+			continue
+		}
+		var others []constraintDomain
+		for j, other := range groups {
+			if j != i && !dropped[j] {
+				others = append(others, other.domain)
+			}
+		}
+		if len(others) > 0 && domainSubsetOfUnion(group.domain, others) {
+			dropped[i] = true
+			diagnostics = append(diagnostics, Diagnostic{
+				Severity: SeverityWarning,
+				Code:     LintUnreachableGroup,
+				Message:  fmt.Sprintf("%s is unreachable: the other OR groups already match every version it does", constraint[group.start:group.end]),
+				Start:    group.start,
+				End:      group.end,
+				Fix:      withoutGroup(constraint, groups, i),
+			})
+		}
+	}
+
+	for i, group := range groups {
+		if dropped[i] {
+			continue
+		}
+		for j := i + 1; j < len(groups); j++ {
+			if dropped[j] || !domainsIntersect(group.domain, groups[j].domain) {
+				continue
+			}
+			fix, _ := Compact(constraint)
+			if fix == constraint {
+				fix = ""
+			}
+			diagnostics = append(diagnostics, Diagnostic{
+				Severity: SeverityInfo,
+				Code:     LintOverlappingGroups,
+				Message:  fmt.Sprintf("%s overlaps %s", constraint[group.start:group.end], constraint[groups[j].start:groups[j].end]),
+				Start:    groups[j].start,
+				End:      groups[j].end,
+				Fix:      fix,
+			})
+		}
+	}
+
+	for i, group := range groups {
+		if dropped[i] || !domainUnbounded(group.domain) {
+			continue
+		}
+		d := Diagnostic{
+			Severity: SeverityWarning,
+			Code:     LintUnbounded,
+			Message:  fmt.Sprintf("%s has no upper bound and will accept future major versions", constraint[group.start:group.end]),
+			Start:    group.start,
+			End:      group.end,
+		}
+		if len(group.terms) == 1 && len(group.terms[0].constraints) == 1 && strings.HasPrefix(group.terms[0].text, ">=") {
+			term := group.terms[0]
+			d.Fix = replaceSpan(constraint, term.start, term.end, "^"+strings.TrimSpace(strings.TrimPrefix(term.text, ">=")))
+		}
+		diagnostics = append(diagnostics, d)
+	}
+	return diagnostics
+}
+
+func domainUnbounded(domain constraintDomain) bool {
+	for _, interval := range domain.numeric {
+		if interval.upper == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// lintStabilityFlags reports @stability flags that have no effect because
+// Composer applies the least stable flag of a requirement.
+func lintStabilityFlags(constraint string, groups []lintGroup) []Diagnostic {
+	type flagged struct {
+		flag       string
+		start, end int
+	}
+	var flags []flagged
+	least := ""
+	for _, group := range groups {
+		for _, term := range group.terms {
+			at := strings.LastIndex(term.text, "@")
+			if at < 0 {
+				continue
+			}
+			flag := strings.ToLower(strings.TrimSpace(term.text[at+1:]))
+			if _, ok := stabilityLevels[flag]; !ok {
+				continue
+			}
+			flags = append(flags, flagged{flag: flag, start: term.start + at, end: term.end})
+			if least == "" || stabilityLevels[flag] < stabilityLevels[least] {
+				least = flag
+			}
+		}
+	}
+
+	var diagnostics []Diagnostic
+	for _, f := range flags {
+		if stabilityLevels[f.flag] == stabilityLevels[least] {
+			continue
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Severity: SeverityWarning,
+			Code:     LintConflictingStability,
+			Message:  fmt.Sprintf("@%s has no effect: Composer applies the least stable flag, @%s", expandStability(f.flag), expandStability(least)),
+			Start:    f.start,
+			End:      f.end,
+			Fix:      replaceSpan(constraint, f.start, f.end, ""),
+		})
+	}
+	return diagnostics
+}
+
+// withoutGroup returns constraint with OR group i removed, or "" when it is
+// the only group.
+func withoutGroup(constraint string, groups []lintGroup, i int) string {
+	var kept []string
+	for j, group := range groups {
+		if j != i {
+			kept = append(kept, constraint[group.start:group.end])
+		}
+	}
+	return strings.Join(kept, " || ")
+}
+
+func replaceSpan(s string, start, end int, replacement string) string {
+	return s[:start] + replacement + s[end:]
+}
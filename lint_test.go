@@ -0,0 +1,144 @@
+package version
+
+import "testing"
+
+func TestLint(t *testing.T) {
+	type diagnostic struct {
+		code     string
+		severity Severity
+		span     string
+		fix      string
+	}
+	tests := []struct {
+		constraint string
+		expected   []diagnostic
+	}{
+		{"^1.2", nil},
+		{"^1.2 || ^2.0", nil},
+		{"dev-main", nil},
+		{">=1.0", []diagnostic{{LintUnbounded, SeverityWarning, ">=1.0", "^1.0"}}},
+		{"*", []diagnostic{{LintUnbounded, SeverityWarning, "*", ""}}},
+		{"*@dev", []diagnostic{{LintUnbounded, SeverityWarning, "*@dev", ""}}},
+		{"1.2.3", []diagnostic{{LintExactPin, SeverityWarning, "1.2.3", "^1.2.3"}}},
+		{"^1.0, == 1.2.3", []diagnostic{{LintExactPin, SeverityWarning, "== 1.2.3", "^1.0, ^1.2.3"}}},
+		{"^1.0 | ^2.0", []diagnostic{{LintSinglePipe, SeverityWarning, "|", "^1.0 || ^2.0"}}},
+		{"^1.0 || ^1.5", []diagnostic{{LintUnreachableGroup, SeverityWarning, "^1.5", "^1.0"}}},
+		{"^1.0 || ^1.0", []diagnostic{{LintUnreachableGroup, SeverityWarning, "^1.0", "^1.0"}}},
+		{"^1.0 || >=1.5 <3.0", []diagnostic{{LintOverlappingGroups, SeverityInfo, ">=1.5 <3.0", ">=1.0 <3.0"}}},
+		{"^2.0, <1.0 || ^3.0", []diagnostic{{LintEmptyGroup, SeverityError, "^2.0, <1.0", "^3.0"}}},
+		{"^1.0@beta || ^2.0@dev", []diagnostic{{LintConflictingStability, SeverityWarning, "@beta", ""}}},
+		{"^1.0@stable || ^2.0@dev", []diagnostic{{LintConflictingStability, SeverityWarning, "@stable", "^1.0 || ^2.0@dev"}}},
+		{"1.0..dev || ^2.0", []diagnostic{
+			{LintExactPin, SeverityWarning, "1.0..dev", "^1.0.0-dev || ^2.0"},
+			{LintTypo, SeverityWarning, "..dev", "1.0-dev || ^2.0"},
+		}},
+		{"1.0..DEV", []diagnostic{
+			{LintExactPin, SeverityWarning, "1.0..DEV", "^1.0.0-dev"},
+			{LintTypo, SeverityWarning, "..DEV", "1.0-DEV"},
+		}},
+		{"1.0..Dev", nil},
+		{"^1.0_-dev, <2.0_-DEV", []diagnostic{
+			{LintTypo, SeverityWarning, "_-dev", "^1.0-dev, <2.0_-DEV"},
+			{LintTypo, SeverityWarning, "_-DEV", "^1.0_-dev, <2.0-DEV"},
+		}},
+		{"v1.2.3", []diagnostic{{LintExactPin, SeverityWarning, "v1.2.3", "^1.2.3"}}},
+		{"=1.2", []diagnostic{{LintExactPin, SeverityWarning, "=1.2", "^1.2.0"}}},
+		{"1.0.0 as 2.0.0", nil},
+		{"^1.0 || 1.2.3 as 1.3.0", []diagnostic{{LintUnreachableGroup, SeverityWarning, "1.2.3", "^1.0"}}},
+		{"^1.0.", []diagnostic{{LintTypo, SeverityWarning, ".", "^1.0"}}},
+		{">=1.0 <", []diagnostic{{LintParseError, SeverityError, "<", ""}}},
+	}
+
+	for _, tc := range tests {
+		actual := Lint(tc.constraint)
+		if len(actual) != len(tc.expected) {
+			t.Errorf("%q: expected %d diagnostics, got %d: %+v", tc.constraint, len(tc.expected), len(actual), actual)
+			continue
+		}
+		for i, expected := range tc.expected {
+			d := actual[i]
+			span := tc.constraint[d.Start:d.End]
+			if d.Code != expected.code || d.Severity != expected.severity || span != expected.span || d.Fix != expected.fix {
+				t.Errorf("%q: expected %s %s %q fix %q, got %s %s %q fix %q (%s)",
+					tc.constraint, expected.severity, expected.code, expected.span, expected.fix,
+					d.Severity, d.Code, span, d.Fix, d.Message)
+			}
+			if d.Message == "" {
+				t.Errorf("%q: %s diagnostic has no message", tc.constraint, d.Code)
+			}
+		}
+	}
+}
+
+func TestLintMessages(t *testing.T) {
+	tests := []struct {
+		constraint string
+		message    string
+	}{
+		{"v1.2.3", "v1.2.3 pins an exact version and blocks bug fixes, consider ^1.2.3"},
+		{">=1.0", ">=1.0 has no upper bound and will accept future major versions"},
+		{"^1.0 || ^1.5", "^1.5 is unreachable: the other OR groups already match every version it does"},
+		{"^2.0, <1.0 || ^3.0", "^2.0, <1.0 can never match: its terms have no version in common"},
+		{"^1.0@beta || ^2.0@dev", "@beta has no effect: Composer applies the least stable flag, @dev"},
+		{"^1.0-.dev", `"-.dev" is read as "-dev"`},
+		{"^1.0..", `trailing ".." in ^1.0.. is ignored`},
+	}
+
+	for _, tc := range tests {
+		diagnostics := Lint(tc.constraint)
+		if len(diagnostics) == 0 {
+			t.Errorf("%q: expected a diagnostic", tc.constraint)
+			continue
+		}
+		if diagnostics[0].Message != tc.message {
+			t.Errorf("%q: expected message %q, got %q", tc.constraint, tc.message, diagnostics[0].Message)
+		}
+	}
+}
+
+func TestLintFixes(t *testing.T) {
+	constraints := []string{
+		"1.2.3", "v1.2.3", "== 1.2.3", "1.0..DEV", "^1.0, 1.2.3", ">=1.0", ">=1.0 || ^0.5",
+		"^1.0 | ^2.0", "^1.0 || ^1.5", "^1.0 || >=1.5 <3.0", "^2.0, <1.0 || ^3.0",
+		"^1.0@stable || ^2.0@dev", "1.0..dev || ^2.0", "^1.0_-dev", "^1.0.", "~1.2. || dev-main",
+	}
+
+	for _, constraint := range constraints {
+		diagnostics := Lint(constraint)
+		for i, d := range diagnostics {
+			if i > 0 && diagnostics[i-1].Start > d.Start {
+				t.Errorf("%q: diagnostics are not sorted by Start", constraint)
+			}
+			if d.Start < 0 || d.Start > d.End || d.End > len(constraint) {
+				t.Errorf("%q: %s span [%d, %d) is out of range", constraint, d.Code, d.Start, d.End)
+			}
+			if d.Fix == "" {
+				continue
+			}
+			if _, err := NewConstraint(d.Fix); err != nil {
+				t.Errorf("%q: %s fix %q does not parse: %v", constraint, d.Code, d.Fix, err)
+				continue
+			}
+			if d.Code == LintExactPin || d.Code == LintUnbounded {
+				// These fixes change the range on purpose.
+				continue
+			}
+			if equivalent, err := ConstraintEquivalent(constraint, d.Fix); err != nil || !equivalent {
+				t.Errorf("%q: %s fix %q does not match the same versions (%v)", constraint, d.Code, d.Fix, err)
+			}
+		}
+	}
+}
+
+func TestSeverityString(t *testing.T) {
+	for severity, expected := range map[Severity]string{
+		SeverityInfo:    "info",
+		SeverityWarning: "warning",
+		SeverityError:   "error",
+		Severity(7):     "Severity(7)",
+	} {
+		if severity.String() != expected {
+			t.Errorf("expected %q, got %q", expected, severity.String())
+		}
+	}
+}
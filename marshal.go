@@ -0,0 +1,296 @@
+package version
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// This file holds the encoding support for Version and Constraints:
+// encoding.TextMarshaler, json.Marshaler and xml.Unmarshaler implementations
+// plus the NormalizedVersion wrapper that selects the canonical string form.
+
+var (
+	versionType           = reflect.TypeOf(Version{})
+	normalizedVersionType = reflect.TypeOf(NormalizedVersion{})
+	constraintsType       = reflect.TypeOf(Constraints{})
+)
+
+// NormalizedVersion wraps a Version so that it marshals as NormalizedString()
+// instead of Original(). Use it as a struct field type when the persisted form
+// should be canonical rather than what the user wrote:
+//
+//	type Manifest struct {
+//		Version version.NormalizedVersion `json:"version"`
+//	}
+//
+// Decoding accepts any version string NewVersion accepts.
+type NormalizedVersion struct {
+	*Version
+}
+
+// MarshalText implements encoding.TextMarshaler. The original version string
+// is emitted; wrap the version in NormalizedVersion to emit the normalized
+// form instead.
+func (v *Version) MarshalText() ([]byte, error) {
+	if v == nil {
+		return []byte{}, nil
+	}
+	return []byte(v.original), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *Version) UnmarshalText(text []byte) error {
+	parsed, err := NewVersion(string(text))
+	if err != nil {
+		return err
+	}
+	*v = *parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The version is encoded as a JSON
+// string holding Original().
+func (v *Version) MarshalJSON() ([]byte, error) {
+	if v == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(v.original)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A JSON null leaves the version
+// untouched. A value that is not a string is reported as
+// *json.UnmarshalTypeError, which encoding/json annotates with the struct
+// field that held it. So is a malformed version string; when encoding/json
+// is backed by json/v2, the default from Go 1.27, the error also wraps the
+// *ParseError, so errors.Is matches its kind, such as ErrMalformedVersion,
+// and errors.As finds its offset and token.
+func (v *Version) UnmarshalJSON(data []byte) error {
+	parsed, err := unmarshalVersionJSON(data, versionType)
+	if err != nil || parsed == nil {
+		return err
+	}
+	*v = *parsed
+	return nil
+}
+
+// UnmarshalXML implements xml.Unmarshaler. Errors name the element that held
+// the malformed version.
+func (v *Version) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	parsed, err := unmarshalVersionXML(d, start)
+	if err != nil {
+		return err
+	}
+	*v = *parsed
+	return nil
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr. Errors name the attribute
+// that held the malformed version.
+func (v *Version) UnmarshalXMLAttr(attr xml.Attr) error {
+	parsed, err := unmarshalVersionXMLAttr(attr)
+	if err != nil {
+		return err
+	}
+	*v = *parsed
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler, emitting NormalizedString().
+func (v NormalizedVersion) MarshalText() ([]byte, error) {
+	if v.Version == nil {
+		return []byte{}, nil
+	}
+	return []byte(v.NormalizedString()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *NormalizedVersion) UnmarshalText(text []byte) error {
+	parsed, err := NewVersion(string(text))
+	if err != nil {
+		return err
+	}
+	v.Version = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, emitting NormalizedString() as a JSON
+// string. An empty wrapper is encoded as null.
+func (v NormalizedVersion) MarshalJSON() ([]byte, error) {
+	if v.Version == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(v.NormalizedString())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *NormalizedVersion) UnmarshalJSON(data []byte) error {
+	parsed, err := unmarshalVersionJSON(data, normalizedVersionType)
+	if err != nil || parsed == nil {
+		return err
+	}
+	v.Version = parsed
+	return nil
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (v *NormalizedVersion) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	parsed, err := unmarshalVersionXML(d, start)
+	if err != nil {
+		return err
+	}
+	v.Version = parsed
+	return nil
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (v *NormalizedVersion) UnmarshalXMLAttr(attr xml.Attr) error {
+	parsed, err := unmarshalVersionXMLAttr(attr)
+	if err != nil {
+		return err
+	}
+	v.Version = parsed
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler. The constraints are rendered
+// as String() does: AND terms joined by "," and OR groups by "||", with hyphen
+// ranges and whitespace-separated terms already expanded into their comma
+// form. Parsing the result with NewConstraint matches exactly the same
+// versions. Empty constraints marshal to an empty string.
+func (cs Constraints) MarshalText() ([]byte, error) {
+	return []byte(cs.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. An empty (or blank)
+// input yields empty constraints, mirroring MarshalText.
+func (cs *Constraints) UnmarshalText(text []byte) error {
+	parsed, err := unmarshalConstraintsString(string(text))
+	if err != nil {
+		return err
+	}
+	*cs = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. Empty constraints encode as null.
+func (cs Constraints) MarshalJSON() ([]byte, error) {
+	if len(cs) == 0 {
+		return []byte("null"), nil
+	}
+	return json.Marshal(cs.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A JSON null leaves the
+// constraints untouched. Errors are reported like Version.UnmarshalJSON.
+func (cs *Constraints) UnmarshalJSON(data []byte) error {
+	raw, ok, err := decodeJSONString(data, constraintsType)
+	if err != nil || !ok {
+		return err
+	}
+
+	parsed, err := unmarshalConstraintsString(raw)
+	if err != nil {
+		return jsonParseError(constraintsType, err)
+	}
+	*cs = parsed
+	return nil
+}
+
+// UnmarshalXML implements xml.Unmarshaler. Errors name the element that held
+// the malformed constraint.
+func (cs *Constraints) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var raw string
+	if err := d.DecodeElement(&raw, &start); err != nil {
+		return err
+	}
+	parsed, err := unmarshalConstraintsString(raw)
+	if err != nil {
+		return fmt.Errorf("invalid constraint in element <%s>: %w", start.Name.Local, err)
+	}
+	*cs = parsed
+	return nil
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr. Errors name the attribute
+// that held the malformed constraint.
+func (cs *Constraints) UnmarshalXMLAttr(attr xml.Attr) error {
+	parsed, err := unmarshalConstraintsString(attr.Value)
+	if err != nil {
+		return fmt.Errorf("invalid constraint in attribute %q: %w", attr.Name.Local, err)
+	}
+	*cs = parsed
+	return nil
+}
+
+func unmarshalConstraintsString(raw string) (Constraints, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+	return NewConstraint(raw)
+}
+
+func unmarshalVersionJSON(data []byte, typ reflect.Type) (*Version, error) {
+	raw, ok, err := decodeJSONString(data, typ)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	parsed, err := NewVersion(raw)
+	if err != nil {
+		return nil, jsonParseError(typ, err)
+	}
+	return parsed, nil
+}
+
+// decodeJSONString extracts the string held by a JSON value. It reports
+// ok=false for a JSON null, which callers treat as a no-op.
+func decodeJSONString(data []byte, typ reflect.Type) (string, bool, error) {
+	if string(data) == "null" {
+		return "", false, nil
+	}
+
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return "", false, &json.UnmarshalTypeError{Value: jsonValueKind(data), Type: typ}
+	}
+	return raw, true, nil
+}
+
+func jsonValueKind(data []byte) string {
+	if len(data) == 0 {
+		return "value"
+	}
+	switch data[0] {
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case 't', 'f':
+		return "bool"
+	default:
+		return "number " + string(data)
+	}
+}
+
+func unmarshalVersionXML(d *xml.Decoder, start xml.StartElement) (*Version, error) {
+	var raw string
+	if err := d.DecodeElement(&raw, &start); err != nil {
+		return nil, err
+	}
+	parsed, err := NewVersion(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version in element <%s>: %w", start.Name.Local, err)
+	}
+	return parsed, nil
+}
+
+func unmarshalVersionXMLAttr(attr xml.Attr) (*Version, error) {
+	parsed, err := NewVersion(attr.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version in attribute %q: %w", attr.Name.Local, err)
+	}
+	return parsed, nil
+}
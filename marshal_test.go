@@ -0,0 +1,275 @@
+package version
+
+import (
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*Version)(nil)
+	_ encoding.TextUnmarshaler = (*Version)(nil)
+	_ json.Marshaler           = (*Version)(nil)
+	_ json.Unmarshaler         = (*Version)(nil)
+	_ xml.Unmarshaler          = (*Version)(nil)
+	_ xml.UnmarshalerAttr      = (*Version)(nil)
+	_ encoding.TextMarshaler   = NormalizedVersion{}
+	_ json.Unmarshaler         = (*NormalizedVersion)(nil)
+)
+
+func TestVersionJSONRoundTrip(t *testing.T) {
+	type manifest struct {
+		Version    *Version          `json:"version"`
+		Normalized NormalizedVersion `json:"normalized"`
+		Missing    *Version          `json:"missing"`
+	}
+
+	input := `{"version":"v1.2.3-beta1","normalized":"v1.2","missing":null}`
+	var decoded manifest
+	if err := json.Unmarshal([]byte(input), &decoded); err != nil {
+		t.Fatalf("unexpected unmarshal error: %v", err)
+	}
+	if decoded.Version.NormalizedString() != "1.2.3.0-beta1" {
+		t.Errorf("expected 1.2.3.0-beta1, got %s", decoded.Version.NormalizedString())
+	}
+	if decoded.Missing != nil {
+		t.Errorf("expected nil version for null, got %v", decoded.Missing)
+	}
+
+	encoded, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatalf("unexpected marshal error: %v", err)
+	}
+	expected := `{"version":"v1.2.3-beta1","normalized":"1.2.0.0","missing":null}`
+	if string(encoded) != expected {
+		t.Errorf("expected %s, got %s", expected, encoded)
+	}
+}
+
+func TestVersionJSONErrorReportsField(t *testing.T) {
+	type manifest struct {
+		Package struct {
+			Version *Version `json:"version"`
+		} `json:"package"`
+	}
+
+	tests := []struct {
+		input string
+		value string
+	}{
+		{`{"package":{"version":"not a version"}}`, "string"},
+		{`{"package":{"version":12}}`, "number 12"},
+	}
+
+	for _, tc := range tests {
+		var decoded manifest
+		err := json.Unmarshal([]byte(tc.input), &decoded)
+		var typeErr *json.UnmarshalTypeError
+		if !errors.As(err, &typeErr) {
+			t.Errorf("Unmarshal(%s): expected *json.UnmarshalTypeError, got %v", tc.input, err)
+			continue
+		}
+		if typeErr.Field != "package.version" {
+			t.Errorf("Unmarshal(%s): expected field package.version, got %q", tc.input, typeErr.Field)
+		}
+		if !strings.HasPrefix(typeErr.Value, tc.value) {
+			t.Errorf("Unmarshal(%s): expected value %q, got %q", tc.input, tc.value, typeErr.Value)
+		}
+	}
+}
+
+func TestVersionXMLRoundTrip(t *testing.T) {
+	type release struct {
+		XMLName    xml.Name          `xml:"release"`
+		Version    *Version          `xml:"version,attr"`
+		Normalized NormalizedVersion `xml:"normalized"`
+	}
+
+	input := `<release version="1.0.0-RC1"><normalized>v2.1</normalized></release>`
+	var decoded release
+	if err := xml.Unmarshal([]byte(input), &decoded); err != nil {
+		t.Fatalf("unexpected unmarshal error: %v", err)
+	}
+
+	encoded, err := xml.Marshal(decoded)
+	if err != nil {
+		t.Fatalf("unexpected marshal error: %v", err)
+	}
+	expected := `<release version="1.0.0-RC1"><normalized>2.1.0.0</normalized></release>`
+	if string(encoded) != expected {
+		t.Errorf("expected %s, got %s", expected, encoded)
+	}
+}
+
+func TestVersionXMLErrorReportsField(t *testing.T) {
+	type release struct {
+		Version *Version `xml:"version,attr"`
+		Target  *Version `xml:"target"`
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`<release version="bogus version"></release>`, `attribute "version"`},
+		{`<release><target>bogus version</target></release>`, "element <target>"},
+	}
+
+	for _, tc := range tests {
+		var decoded release
+		err := xml.Unmarshal([]byte(tc.input), &decoded)
+		if err == nil || !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("Unmarshal(%s): expected error mentioning %s, got %v", tc.input, tc.expected, err)
+		}
+	}
+}
+
+func TestVersionTextRoundTrip(t *testing.T) {
+	var v Version
+	if err := v.UnmarshalText([]byte("1.0.0.0-alpha2")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text, err := v.MarshalText()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(text) != "1.0.0.0-alpha2" {
+		t.Errorf("expected 1.0.0.0-alpha2, got %s", text)
+	}
+
+	if err := v.UnmarshalText([]byte("not a version")); err == nil {
+		t.Error("expected error for malformed version")
+	}
+}
+
+func TestConstraintsTextRoundTrip(t *testing.T) {
+	constraints := []string{
+		"^1.2",
+		"~1.2.3",
+		"1.0 - 2.0",
+		"1.2.3 - 2.3.4",
+		">=1.0 <2.0",
+		">= 1.0 < 2.0 || ^3.0",
+		"^1.0 | ^2.0",
+		"1.2.*",
+		"*",
+		">=1.0@stable",
+		"^1.0@beta",
+		"@dev",
+		"!=1.5.0, ^1.0",
+		"dev-main || ^2.0",
+		"2.1.x-dev",
+		"1.0.0-beta2 - 1.2",
+		"1.0 as 2.0",
+	}
+	versions := []string{
+		"0.9", "1.0.0-dev", "1.0.0-alpha1", "1.0.0-beta2", "1.0.0", "1.1.5", "1.2.0-RC1", "1.2.0",
+		"1.2.3", "1.2.9", "1.3.0", "1.5.0", "1.9.9", "2.0.0-beta1", "2.0.0", "2.0.5", "2.1.0",
+		"2.1.x-dev", "2.3.4", "2.3.5", "3.0.0", "3.4.0", "dev-main", "dev-feature",
+	}
+
+	for _, raw := range constraints {
+		original := MustConstraints(NewConstraint(raw))
+		text, err := original.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText(%q) unexpected error: %v", raw, err)
+		}
+
+		var decoded Constraints
+		if err := decoded.UnmarshalText(text); err != nil {
+			t.Errorf("UnmarshalText(%q) from %q unexpected error: %v", text, raw, err)
+			continue
+		}
+
+		for _, rawVersion := range versions {
+			v := Must(NewVersion(rawVersion))
+			if original.Check(v) != decoded.Check(v) {
+				t.Errorf("%q marshaled as %q: Check(%s) changed from %v to %v", raw, text, rawVersion, original.Check(v), decoded.Check(v))
+			}
+		}
+	}
+}
+
+func TestConstraintsJSONRoundTrip(t *testing.T) {
+	type requirement struct {
+		Require  Constraints `json:"require"`
+		Conflict Constraints `json:"conflict"`
+	}
+
+	input := `{"require":"1.0 - 2.0 || >=3.0 <3.5","conflict":null}`
+	var decoded requirement
+	if err := json.Unmarshal([]byte(input), &decoded); err != nil {
+		t.Fatalf("unexpected unmarshal error: %v", err)
+	}
+	if decoded.Conflict != nil {
+		t.Errorf("expected nil constraints for null, got %v", decoded.Conflict)
+	}
+
+	encoded, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatalf("unexpected marshal error: %v", err)
+	}
+	expected := `{"require":"\u003e=1.0,\u003c2.1.0||\u003e=3.0,\u003c3.5","conflict":null}`
+	if string(encoded) != expected {
+		t.Errorf("expected %s, got %s", expected, encoded)
+	}
+
+	var again requirement
+	if err := json.Unmarshal(encoded, &again); err != nil {
+		t.Fatalf("unexpected unmarshal error: %v", err)
+	}
+	for _, rawVersion := range []string{"0.9", "1.0.0", "2.0.9", "2.1.0", "3.0.0", "3.4.9", "3.5.0"} {
+		v := Must(NewVersion(rawVersion))
+		if decoded.Require.Check(v) != again.Require.Check(v) {
+			t.Errorf("Check(%s) changed after JSON round trip", rawVersion)
+		}
+	}
+}
+
+func TestConstraintsDecodeErrors(t *testing.T) {
+	var jsonTarget struct {
+		Require Constraints `json:"require"`
+	}
+	err := json.Unmarshal([]byte(`{"require":">>1.0"}`), &jsonTarget)
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) {
+		t.Errorf("expected *json.UnmarshalTypeError, got %v", err)
+	}
+
+	var xmlTarget struct {
+		Require Constraints `xml:"require"`
+	}
+	err = xml.Unmarshal([]byte(`<package><require>&gt;&gt;1.0</require></package>`), &xmlTarget)
+	if err == nil || !strings.Contains(err.Error(), "element <require>") {
+		t.Errorf("expected error mentioning element <require>, got %v", err)
+	}
+}
+
+func TestSemverMarshalsPlainStrings(t *testing.T) {
+	type chart struct {
+		Version    *Version          `json:"version"`
+		Normalized NormalizedVersion `json:"normalized"`
+		Require    Constraints       `json:"require"`
+	}
+
+	encoded, err := json.Marshal(chart{
+		Version:    Must(NewSemver("1.0.0-alpha.beta+build.7")),
+		Normalized: NormalizedVersion{Must(NewSemver("2.0.0-rc.1"))},
+		Require:    MustConstraints(NewConstraintWithOptions(">=1.0.0-alpha.beta, <2.0.0", ParseOptions{Scheme: SemVer2})),
+	})
+	if err != nil {
+		t.Fatalf("unexpected marshal error: %v", err)
+	}
+	expected := `{"version":"1.0.0-alpha.beta+build.7","normalized":"2.0.0-rc.1","require":"\u003e=1.0.0-alpha.beta,\u003c2.0.0"}`
+	if string(encoded) != expected {
+		t.Errorf("expected %s, got %s", expected, encoded)
+	}
+
+	text, err := Must(NewSemver("1.0.0-rc.1")).MarshalText()
+	if err != nil || string(text) != "1.0.0-rc.1" {
+		t.Errorf("MarshalText: expected 1.0.0-rc.1, got (%s, %v)", text, err)
+	}
+}
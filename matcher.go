@@ -0,0 +1,117 @@
+package version
+
+import (
+	"sort"
+	"strings"
+)
+
+// This file holds Matcher, the compiled form of Constraints for checking
+// many versions against the same constraint.
+
+// Matcher checks versions against compiled constraints. It gives the same
+// results as Constraints.Check but looks a numeric version up in the sorted
+// interval set of the constraints with a binary search instead of running
+// every term. Build one with Compile; a Matcher is safe for concurrent use.
+type Matcher struct {
+	constraints Constraints
+
+	// intervals are the sorted, disjoint numeric intervals of the compiled
+	// groups; their bounds never carry exclusions.
+	intervals []versionInterval
+	branches  branchSnapshot
+
+	// fallback holds the groups the interval model does not describe
+	// exactly, which are checked term by term: stability filters such as a
+	// bare "@beta", "-stable" bounds, dev references and SemVer2 terms.
+	fallback Constraints
+}
+
+// Compile precomputes the interval set of cs.
+func Compile(cs Constraints) *Matcher {
+	m := &Matcher{constraints: cs}
+
+	var domains []constraintDomain
+	for _, group := range cs {
+		domain, ok := compileGroup(group)
+		if !ok {
+			m.fallback = append(m.fallback, group)
+			continue
+		}
+		domains = append(domains, domain)
+	}
+	m.intervals = mergeNumericIntervals(unionNumericIntervals(domains))
+	m.branches = snapshotBranches(domains)
+	return m
+}
+
+func compileGroup(group []*Constraint) (constraintDomain, bool) {
+	for _, c := range group {
+		if !compilable(c) {
+			return constraintDomain{}, false
+		}
+	}
+	domain, err := constraintsDomain(group)
+	return domain, err == nil
+}
+
+// compilable reports whether the domain of c matches exactly the versions
+// c.Check accepts.
+func compilable(c *Constraint) bool {
+	if c.stability != "" {
+		return false
+	}
+	if c.check == nil {
+		return true
+	}
+	return c.check.scheme == Composer && !c.stableBound && !strings.Contains(c.original, "#")
+}
+
+// Check reports whether v satisfies the compiled constraints.
+func (m *Matcher) Check(v *Version) bool {
+	if v.scheme == SemVer2 {
+		// SemVer2 precedence does not follow the interval bounds, which are
+		// Composer versions.
+		return m.constraints.Check(v)
+	}
+	if m.matches(v) {
+		return true
+	}
+	return len(m.fallback) > 0 && m.fallback.Check(v)
+}
+
+func (m *Matcher) matches(v *Version) bool {
+	if v.branch != "" {
+		i := sort.SearchStrings(m.branches.Names, v.branch)
+		named := i < len(m.branches.Names) && m.branches.Names[i] == v.branch
+		return named != m.branches.Exclude
+	}
+
+	// Find the first interval whose upper bound does not lie below v.
+	i := sort.Search(len(m.intervals), func(i int) bool {
+		upper := m.intervals[i].upper
+		if upper == nil {
+			return true
+		}
+		cmp := v.Compare(upper.version)
+		return cmp < 0 || (cmp == 0 && upper.inclusive)
+	})
+	if i == len(m.intervals) {
+		return false
+	}
+	lower := m.intervals[i].lower
+	if lower == nil {
+		return true
+	}
+	cmp := v.Compare(lower.version)
+	return cmp > 0 || (cmp == 0 && lower.inclusive)
+}
+
+// Constraints returns the constraints m was compiled from.
+func (m *Matcher) Constraints() Constraints {
+	return m.constraints
+}
+
+// String returns the string form of the compiled constraints.
+func (m *Matcher) String() string {
+	return m.constraints.String()
+}
@@ -0,0 +1,68 @@
+package version
+
+import "testing"
+
+func TestMatcherMatchesCheck(t *testing.T) {
+	var versions []*Version
+	constraints := []string{
+		"*", "@beta", "@stable", "^1.0 || @dev", ">=1.0@stable", ">=1.0-stable", "^1.2-stable", "<2.0-stable",
+		"1.*", "1.2.*", "!=1.*", ">=1.*", "<1.2.*", "1.x-dev", "1.0.x-dev#abc123", "dev-main", "!=dev-main",
+		">dev-main", "^1.0 || dev-foo", "^1.2, !=1.5.0 || ~2.1.0", "1.0 - 2.0", "1.0.0 - 2.0.0", ">2.0 <1.0",
+		"^0.3 || ^0.0.3", "~1.2.3.4", "2010-01-02",
+	}
+	for _, major := range []string{"0", "1", "2"} {
+		for _, minor := range []string{"0", "1", "3"} {
+			for _, patch := range []string{"", ".0", ".3"} {
+				for _, pre := range []string{"", "-dev", "-alpha1", "-beta2", "-RC1", "-p1"} {
+					raw := major + "." + minor + patch + pre
+					versions = append(versions, Must(NewVersion(raw)))
+					for _, op := range []string{"", "!=", ">", ">=", "<", "<=", "^", "~"} {
+						constraints = append(constraints, op+raw)
+					}
+				}
+			}
+		}
+	}
+	for _, raw := range []string{"dev-main", "dev-foo", "1.x-dev", "1.0.x-dev", "2010-01-02", "1.0.0+build.1"} {
+		versions = append(versions, Must(NewVersion(raw)))
+	}
+
+	for _, constraint := range constraints {
+		cs := MustConstraints(NewConstraint(constraint))
+		m := Compile(cs)
+		for _, v := range versions {
+			if m.Check(v) != cs.Check(v) {
+				t.Errorf("%q vs %s: Matcher says %t, Check says %t", constraint, v.Original(), m.Check(v), cs.Check(v))
+			}
+		}
+	}
+}
+
+func TestMatcherSemver(t *testing.T) {
+	cs, err := NewConstraintWithOptions("^1.2.0 || 2.0.0-rc.1", ParseOptions{Scheme: SemVer2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := Compile(cs)
+	for _, raw := range []string{"1.2.0", "1.9.9", "2.0.0-rc.1", "2.0.0-rc.2", "2.0.0", "1.1.0"} {
+		v := Must(NewSemver(raw))
+		if m.Check(v) != cs.Check(v) {
+			t.Errorf("%s: Matcher says %t, Check says %t", raw, m.Check(v), cs.Check(v))
+		}
+	}
+
+	composer := Compile(MustConstraints(NewConstraint("^1.2")))
+	for _, raw := range []string{"1.2.0", "1.3.0-beta.2", "2.0.0"} {
+		v := Must(NewSemver(raw))
+		if composer.Check(v) != composer.Constraints().Check(v) {
+			t.Errorf("%s: Matcher disagrees with Check for a SemVer2 version", raw)
+		}
+	}
+}
+
+func TestMatcherString(t *testing.T) {
+	m := Compile(MustConstraints(NewConstraint("^1.0 || ^2.0")))
+	if m.String() != "^1.0||^2.0" {
+		t.Errorf("expected %q, got %q", "^1.0||^2.0", m.String())
+	}
+}
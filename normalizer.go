@@ -24,6 +24,19 @@ var (
 	reNumericBranch     = regexp.MustCompile(`(?i)^v?(\d+)(\.(\d+|[xX*]))?(\.(\d+|[xX*]))?(\.(\d+|[xX*]))?$`)
 )
 
+// versionAnnotations holds the parts of a version string the normalizer
+// strips before canonicalizing it: the " as " alias, the "@" stability flag
+// and the "+" build metadata. None of them take part in comparison. date and
+// branch record which path recognized the version; branch includes numeric
+// branches such as "1.x-dev".
+type versionAnnotations struct {
+	alias     string
+	stability string
+	metadata  string
+	date      bool
+	branch    bool
+}
+
 func normalizeVersion(version string) (string, error) {
 	return normalizeVersionWithContext(version, version)
 }
@@ -77,6 +90,15 @@ func fastNumericNormalize(version string) (string, bool) {
 }
 
 func normalizeVersionWithContext(version, fullVersion string) (string, error) {
+	normalized, _, err := normalizeAnnotatedVersion(version, fullVersion)
+	return normalized, err
+}
+
+// normalizeAnnotatedVersion is normalizeVersionWithContext that also reports
+// what it stripped from the input.
+func normalizeAnnotatedVersion(version, fullVersion string) (string, versionAnnotations, error) {
+	var annotations versionAnnotations
+	input := version
 	version = strings.TrimSpace(version)
 	invalidVersion := version
 	fullVersion = strings.TrimSpace(fullVersion)
@@ -91,17 +113,19 @@ func normalizeVersionWithContext(version, fullVersion string) (string, error) {
 	// it copies the raw digit substrings verbatim so leading zeros are
 	// preserved exactly as the regexp path would. Anything else falls through.
 	if normalized, ok := fastNumericNormalize(version); ok {
-		return normalized, nil
+		return normalized, annotations, nil
 	}
 
 	// Strip off aliasing e.g. "1.2.3 as 1.2.3-alias"
 	if match := reAlias.FindStringSubmatch(version); match != nil {
 		version = match[1]
+		annotations.alias = match[2]
 	}
 
 	// Strip off stability flag e.g. "1.2.3@beta"
 	if match := reStability.FindStringSubmatch(version); match != nil {
 		version = version[:len(version)-len(match[0])]
+		annotations.stability = match[0][1:]
 	}
 
 	// Normalize master/trunk/default branches to dev-branch.
@@ -112,11 +136,13 @@ func normalizeVersionWithContext(version, fullVersion string) (string, error) {
 
 	// If the requirement is branch-like (starts with dev-), use a normalized branch name.
 	if strings.HasPrefix(strings.ToLower(version), "dev-") {
-		return "dev-" + version[4:], nil
+		annotations.branch = true
+		return "dev-" + version[4:], annotations, nil
 	}
 
 	// Strip off build metadata: e.g. "1.2.3+buildinfo"
 	if match := reBuild.FindStringSubmatch(version); match != nil {
+		annotations.metadata = version[len(match[1])+1:]
 		version = match[1]
 	}
 
@@ -146,6 +172,7 @@ func normalizeVersionWithContext(version, fullVersion string) (string, error) {
 		if matches = reDate.FindStringSubmatch(version); matches != nil {
 			// Replace any non-digit character with a dot.
 			version = reNonDigit.ReplaceAllString(matches[1], ".")
+			annotations.date = true
 			// Modifier (if any) is expected at index 2.
 			modifierIndex = 2
 		}
@@ -157,7 +184,7 @@ func normalizeVersionWithContext(version, fullVersion string) (string, error) {
 		if len(matches) > modifierIndex && matches[modifierIndex] != "" {
 			// If the modifier equals "stable", just return the version.
 			if strings.ToLower(matches[modifierIndex]) == "stable" {
-				return version, nil
+				return version, annotations, nil
 			}
 			// Append the expanded stability and any extra numeric part.
 			version += "-" + expandStability(matches[modifierIndex])
@@ -173,7 +200,7 @@ func normalizeVersionWithContext(version, fullVersion string) (string, error) {
 		if len(matches) > modifierIndex+2 && matches[modifierIndex+2] != "" {
 			version += "-dev"
 		}
-		return version, nil
+		return version, annotations, nil
 	}
 
 	// Match dev branches such as "feature-dev" or "feature.dev"
@@ -182,7 +209,8 @@ func normalizeVersionWithContext(version, fullVersion string) (string, error) {
 		// Suffix-style arbitrary branches are accepted, but Composer only
 		// applies this conversion to simple strings.
 		if canConvertDevSuffix(base) {
-			return normalizeBranch(base), nil
+			annotations.branch = true
+			return normalizeBranch(base), annotations, nil
 		}
 	}
 
@@ -202,7 +230,7 @@ func normalizeVersionWithContext(version, fullVersion string) (string, error) {
 		}
 	}
 
-	return "", fmt.Errorf(`invalid version string "%s"%s`, invalidVersion, extraMessage)
+	return "", annotations, newParseError(ErrMalformedVersion, input, version, fmt.Sprintf(`invalid version string "%s"%s`, invalidVersion, extraMessage))
 }
 
 func canConvertDevSuffix(base string) bool {
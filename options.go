@@ -0,0 +1,125 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+)
+
+// This file holds NewVersionWithOptions and the switches that narrow what the
+// Composer normalizer accepts, for validating release tags where a typo must
+// not silently become a dev branch.
+
+// PrefixRule controls whether a version may start with a "v".
+type PrefixRule int
+
+const (
+	// PrefixOptional accepts versions with and without a "v" prefix.
+	PrefixOptional PrefixRule = iota
+	// PrefixRequired rejects versions that do not start with "v" or "V".
+	PrefixRequired
+	// PrefixForbidden rejects versions that start with "v" or "V".
+	PrefixForbidden
+)
+
+// ParseOptions controls how NewVersionWithOptions and
+// NewConstraintWithOptions parse their input. The zero value parses like
+// NewVersion.
+//
+// The Reject*, MaxSegments and Prefix switches apply to the Composer scheme;
+// NewConstraintWithOptions ignores them. SemVer2 already rejects branches,
+// dates, flags, aliases, leading zeros and the "v" prefix.
+type ParseOptions struct {
+	Scheme Scheme
+
+	// RejectBranches rejects dev branches such as "dev-main", "master" or
+	// "1.x-dev".
+	RejectBranches bool
+	// RejectDates rejects date versions such as "20100102" or "2010-01-02".
+	RejectDates bool
+	// RejectStabilityFlags rejects an "@beta"-style suffix.
+	RejectStabilityFlags bool
+	// RejectAliases rejects a "1.0.x-dev as 1.0.0" alias.
+	RejectAliases bool
+	// RejectLeadingZeros rejects numeric segments such as "01".
+	RejectLeadingZeros bool
+	// MaxSegments caps the number of numeric segments written; 0 means no
+	// cap beyond the normalizer's own limit of four.
+	MaxSegments int
+	// Prefix requires or forbids the "v" prefix.
+	Prefix PrefixRule
+}
+
+// NewVersionWithOptions parses v according to opts. Versions rejected by one
+// of the switches are reported as *ParseError with Kind ErrMalformedVersion.
+func NewVersionWithOptions(v string, opts ParseOptions) (*Version, error) {
+	switch opts.Scheme {
+	case Composer:
+	case SemVer2:
+		return NewSemver(v)
+	default:
+		return nil, fmt.Errorf("unknown version scheme: %d", opts.Scheme)
+	}
+
+	version, annotations, err := newAnnotatedVersion(v, versionRegexp)
+	if err != nil {
+		return nil, err
+	}
+	if err := opts.validate(v, version, annotations); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+func (opts ParseOptions) validate(v string, version *Version, annotations versionAnnotations) error {
+	trimmed := strings.TrimSpace(v)
+	if opts.RejectAliases && annotations.alias != "" {
+		return newParseError(ErrMalformedVersion, v, annotations.alias, "alias not allowed: "+v)
+	}
+	if opts.RejectStabilityFlags && annotations.stability != "" {
+		return newParseError(ErrMalformedVersion, v, "@"+annotations.stability, "stability flag not allowed: "+v)
+	}
+	if annotations.branch {
+		if opts.RejectBranches {
+			return newParseError(ErrMalformedVersion, v, trimmed, "branch version not allowed: "+v)
+		}
+		return nil
+	}
+	if opts.RejectDates && annotations.date {
+		return newParseError(ErrMalformedVersion, v, numericCore(trimmed), "date version not allowed: "+v)
+	}
+
+	hasPrefix := trimmed != "" && (trimmed[0] == 'v' || trimmed[0] == 'V')
+	switch {
+	case opts.Prefix == PrefixRequired && !hasPrefix:
+		return newParseError(ErrMalformedVersion, v, trimmed, "missing v prefix: "+v)
+	case opts.Prefix == PrefixForbidden && hasPrefix:
+		return newParseError(ErrMalformedVersion, v, trimmed[:1], "v prefix not allowed: "+v)
+	}
+
+	core := numericCore(trimmed)
+	segments := strings.Split(core, ".")
+	if opts.MaxSegments > 0 && len(segments) > opts.MaxSegments {
+		return newParseError(ErrMalformedVersion, v, core, fmt.Sprintf("more than %d segments: %s", opts.MaxSegments, v))
+	}
+	if opts.RejectLeadingZeros {
+		for _, segment := range segments {
+			if len(segment) > 1 && segment[0] == '0' {
+				return newParseError(ErrMalformedVersion, v, segment, "leading zero not allowed: "+v)
+			}
+		}
+	}
+	return nil
+}
+
+// numericCore returns the dotted digits a version string starts with, after
+// an optional "v" prefix.
+func numericCore(version string) string {
+	if len(version) > 1 && (version[0] == 'v' || version[0] == 'V') {
+		version = version[1:]
+	}
+	end := 0
+	for end < len(version) && (version[end] == '.' || (version[end] >= '0' && version[end] <= '9')) {
+		end++
+	}
+	return strings.TrimRight(version[:end], ".")
+}
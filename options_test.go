@@ -0,0 +1,56 @@
+package version
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewVersionWithOptions(t *testing.T) {
+	tests := []struct {
+		version string
+		opts    ParseOptions
+		token   string // offending token, empty when the version is accepted
+	}{
+		{"dev-feature/x", ParseOptions{}, ""},
+		{"dev-feature/x", ParseOptions{RejectBranches: true}, "dev-feature/x"},
+		{"master", ParseOptions{RejectBranches: true}, "master"},
+		{"1.x-dev", ParseOptions{RejectBranches: true}, "1.x-dev"},
+		{"1.0.0-dev", ParseOptions{RejectBranches: true}, ""},
+		{"20100102", ParseOptions{}, ""},
+		{"20100102", ParseOptions{RejectDates: true}, "20100102"},
+		{"2010-01-02", ParseOptions{RejectDates: true}, "2010"},
+		{"1.0.0@beta", ParseOptions{}, ""},
+		{"1.0.0@beta", ParseOptions{RejectStabilityFlags: true}, "@beta"},
+		{"1.0.x-dev as 1.0.0", ParseOptions{RejectAliases: true}, "1.0.0"},
+		{"1.0.x-dev as 1.0.0", ParseOptions{RejectBranches: true}, "1.0.x-dev as 1.0.0"},
+		{"1.2.3.4", ParseOptions{MaxSegments: 3}, "1.2.3.4"},
+		{"1.2.3", ParseOptions{MaxSegments: 3}, ""},
+		{"1.02.3", ParseOptions{RejectLeadingZeros: true}, "02"},
+		{"1.0.3", ParseOptions{RejectLeadingZeros: true}, ""},
+		{"1.2.3", ParseOptions{Prefix: PrefixRequired}, "1.2.3"},
+		{"v1.2.3", ParseOptions{Prefix: PrefixRequired}, ""},
+		{"V1.2.3", ParseOptions{Prefix: PrefixForbidden}, "V"},
+		{"1.2.3-beta1", ParseOptions{Prefix: PrefixForbidden, MaxSegments: 3, RejectLeadingZeros: true}, ""},
+	}
+
+	for _, tc := range tests {
+		v, err := NewVersionWithOptions(tc.version, tc.opts)
+		if tc.token == "" {
+			if err != nil {
+				t.Errorf("%q with %+v: unexpected error: %s", tc.version, tc.opts, err)
+			} else if v.Original() != tc.version {
+				t.Errorf("%q: expected original to be kept, got %q", tc.version, v.Original())
+			}
+			continue
+		}
+
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) || !errors.Is(err, ErrMalformedVersion) {
+			t.Errorf("%q with %+v: expected *ParseError, got %v", tc.version, tc.opts, err)
+			continue
+		}
+		if parseErr.Token != tc.token || tc.version[parseErr.Offset:parseErr.Offset+len(tc.token)] != tc.token {
+			t.Errorf("%q with %+v: expected token %q, got %q at %d", tc.version, tc.opts, tc.token, parseErr.Token, parseErr.Offset)
+		}
+	}
+}
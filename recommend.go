@@ -0,0 +1,48 @@
+package version
+
+import "strings"
+
+// This file holds RecommendedConstraint, the constraint "composer require"
+// writes for a version it picked.
+
+// defaultBranchAlias is the alias Composer gives the default branch of a
+// package without a branch alias of its own.
+const defaultBranchAlias = "9999999-dev"
+
+// RecommendedConstraint returns the constraint "composer require" writes to
+// composer.json for v, like Composer's
+// VersionSelector::findRecommendedRequireVersion. A release is required with
+// a caret on its major and minor version, so upgrades through minor versions
+// are allowed, and a prerelease adds its stability as a flag:
+//
+//	1.2.3        ^1.2
+//	0.3.1        ^0.3
+//	0.0.4        ^0.0.4
+//	2.0.0-beta2  ^2.0@beta
+//
+// Dev versions are required as they are, except that a branch with a numeric
+// branch alias, such as "dev-main as 2.1.x-dev", is required through the
+// alias: 2.1.x-dev. Date versions are required as they are.
+func RecommendedConstraint(v *Version) string {
+	stability := getVersionStability(v)
+	if stability == "dev" {
+		if alias := v.Alias(); alias != nil && alias.branch == "" && hasWildcardSegment(alias) && alias.NormalizedString() != defaultBranchAlias {
+			return alias.Pretty()
+		}
+		return v.Pretty()
+	}
+	if v.scheme == Composer && strings.Count(v.NormalizedString(), ".") != 3 {
+		// Date versions such as 20100102 do not normalize to four segments.
+		return v.NormalizedString()
+	}
+
+	keep := 2
+	if v.segments[0] == 0 && v.segments[1] == 0 {
+		keep = 3
+	}
+	constraint := "^" + renderSegments(v.segments[:keep], keep)
+	if stability != "stable" {
+		constraint += "@" + expandStability(stability)
+	}
+	return constraint
+}
@@ -0,0 +1,72 @@
+package version
+
+import "testing"
+
+func TestRecommendedConstraint(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected string
+	}{
+		{"1.2.3", "^1.2"},
+		{"v1.2.3", "^1.2"},
+		{"1.0.0", "^1.0"},
+		{"1.2.3.4", "^1.2"},
+		{"10.4", "^10.4"},
+		{"0.3.1", "^0.3"},
+		{"0.0.4", "^0.0.4"},
+		{"2.0.0-beta2", "^2.0@beta"},
+		{"2.0.0-alpha1", "^2.0@alpha"},
+		{"2.1.0-RC3", "^2.1@RC"},
+		{"1.2.3-p1", "^1.2"},
+		{"1.2.3+build.5", "^1.2"},
+		{"dev-main", "dev-main"},
+		{"dev-main as 2.1.x-dev", "2.1.x-dev"},
+		{"dev-main as 2.x-dev", "2.x-dev"},
+		{"dev-main as 9999999-dev", "dev-main"},
+		{"dev-main as 2.1.0", "dev-main"},
+		{"1.0.x-dev", "1.0.x-dev"},
+		{"1.0.0-dev", "1.0.0-dev"},
+		{"20100102", "20100102"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if got := RecommendedConstraint(Must(NewVersion(tt.version))); got != tt.expected {
+				t.Errorf("RecommendedConstraint(%q) = %q, expected %q", tt.version, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRecommendedConstraintSemver(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected string
+	}{
+		{"1.2.3", "^1.2"},
+		{"0.3.1", "^0.3"},
+		{"2.0.0-beta.2", "^2.0@beta"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if got := RecommendedConstraint(Must(NewSemver(tt.version))); got != tt.expected {
+				t.Errorf("RecommendedConstraint(%q) = %q, expected %q", tt.version, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRecommendedConstraintSatisfied(t *testing.T) {
+	for _, raw := range []string{"1.2.3", "0.3.1", "0.0.4", "2.0.0-beta2", "2.1.0-RC3", "dev-main", "1.0.x-dev"} {
+		v := Must(NewVersion(raw))
+		recommended := RecommendedConstraint(v)
+		cs, err := NewConstraint(recommended)
+		if err != nil {
+			t.Fatalf("RecommendedConstraint(%q) = %q does not parse: %v", raw, recommended, err)
+		}
+		if !cs.Check(v) {
+			t.Errorf("RecommendedConstraint(%q) = %q does not match the version", raw, recommended)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package version
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// This file holds the grouping of a Collection into release lines such as
+// 1.x or 1.2.x.
+
+// ReleaseLine is a group of releases sharing their leading segments, such as
+// every 1.2.x release.
+type ReleaseLine struct {
+	// Name is the shared segments joined by dots, such as "1" or "1.2".
+	Name string
+	// Prefix is the shared segments.
+	Prefix []int
+	// Versions holds the releases of the line, sorted from lowest to
+	// highest.
+	Versions Collection
+	// LatestStable is the highest stable release, or nil if the line has
+	// only prereleases. Patch releases such as 1.2.0-p1 count as stable.
+	LatestStable *Version
+	// LatestPrerelease is the highest prerelease, or nil if the line has
+	// none. It may be lower than LatestStable.
+	LatestPrerelease *Version
+	// Superseded reports whether a later line has a stable release.
+	// Prereleases of a later line, such as 2.0.0-beta1, do not supersede
+	// the 1.x line.
+	Superseded bool
+}
+
+// GroupByMajor groups v into one release line per major version; see
+// GroupBy.
+func (v Collection) GroupByMajor() []ReleaseLine {
+	return v.GroupBy(1)
+}
+
+// GroupByMinor groups v into one release line per major.minor version; see
+// GroupBy.
+func (v Collection) GroupByMinor() []ReleaseLine {
+	return v.GroupBy(2)
+}
+
+// GroupBy groups v into release lines of the versions sharing their first
+// depth segments, ordered from the lowest line to the highest. A depth of 3
+// groups 1.2.3 and 1.2.3.4 together, and missing segments count as zero, so
+// 1.2 falls in the 1.2.0 line. Depths below 1 are treated as 1.
+//
+// Prereleases belong to the line of the release they precede: 2.0.0-beta1 is
+// in the 2.x line. Branches such as dev-main and 2.1.x-dev belong to no line
+// and are left out.
+func (v Collection) GroupBy(depth int) []ReleaseLine {
+	depth = max(depth, 1)
+
+	var lines []ReleaseLine
+	for _, version := range v.Sorted() {
+		if version.branch != "" || hasWildcardSegment(version) {
+			continue
+		}
+		prefix := releaseLinePrefix(version, depth)
+		if len(lines) == 0 || !slices.Equal(lines[len(lines)-1].Prefix, prefix) {
+			lines = append(lines, ReleaseLine{Name: releaseLineName(prefix), Prefix: prefix})
+		}
+		lines[len(lines)-1].Versions = append(lines[len(lines)-1].Versions, version)
+	}
+
+	superseded := false
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := &lines[i]
+		line.LatestStable = line.Versions.Stable().Latest()
+		line.LatestPrerelease = line.Versions.prereleases().Latest()
+		line.Superseded = superseded
+		if line.LatestStable != nil {
+			superseded = true
+		}
+	}
+	return lines
+}
+
+// prereleases returns the versions in v that are not stable.
+func (v Collection) prereleases() Collection {
+	var prereleases Collection
+	for _, version := range v {
+		if getVersionStability(version) != "stable" {
+			prereleases = append(prereleases, version)
+		}
+	}
+	return prereleases
+}
+
+func releaseLinePrefix(v *Version, depth int) []int {
+	prefix := make([]int, depth)
+	for i := range prefix {
+		if i < len(v.segments) {
+			prefix[i] = int(v.segments[i])
+		}
+	}
+	return prefix
+}
+
+func releaseLineName(prefix []int) string {
+	parts := make([]string, len(prefix))
+	for i, segment := range prefix {
+		parts[i] = strconv.Itoa(segment)
+	}
+	return strings.Join(parts, ".")
+}
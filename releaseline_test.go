@@ -0,0 +1,84 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func describeReleaseLines(lines []ReleaseLine) string {
+	described := make([]string, len(lines))
+	for i, line := range lines {
+		described[i] = fmt.Sprintf("%s[%s] stable=%s pre=%s superseded=%t",
+			line.Name, collectionOriginals(line.Versions), versionOriginal(line.LatestStable), versionOriginal(line.LatestPrerelease), line.Superseded)
+	}
+	return strings.Join(described, "\n")
+}
+
+func TestCollectionGroupBy(t *testing.T) {
+	collection := newCollection(t,
+		"2.0.0-beta1", "1.0.0", "1.1.0", "1.1.1", "dev-main", "1.2.0-RC1", "1.1.1-p1",
+		"2.1.x-dev", "0.9", "3.0.0-alpha1", "2.0.0", "1.1.1.1",
+	)
+
+	majorLines := []string{
+		"0[0.9] stable=0.9 pre=<nil> superseded=true",
+		"1[1.0.0 1.1.0 1.1.1 1.1.1-p1 1.1.1.1 1.2.0-RC1] stable=1.1.1.1 pre=1.2.0-RC1 superseded=true",
+		"2[2.0.0-beta1 2.0.0] stable=2.0.0 pre=2.0.0-beta1 superseded=false",
+		"3[3.0.0-alpha1] stable=<nil> pre=3.0.0-alpha1 superseded=false",
+	}
+
+	tests := []struct {
+		name     string
+		lines    []ReleaseLine
+		expected []string
+	}{
+		{"major", collection.GroupByMajor(), majorLines},
+		{"minor", collection.GroupByMinor(), []string{
+			"0.9[0.9] stable=0.9 pre=<nil> superseded=true",
+			"1.0[1.0.0] stable=1.0.0 pre=<nil> superseded=true",
+			"1.1[1.1.0 1.1.1 1.1.1-p1 1.1.1.1] stable=1.1.1.1 pre=<nil> superseded=true",
+			"1.2[1.2.0-RC1] stable=<nil> pre=1.2.0-RC1 superseded=true",
+			"2.0[2.0.0-beta1 2.0.0] stable=2.0.0 pre=2.0.0-beta1 superseded=false",
+			"3.0[3.0.0-alpha1] stable=<nil> pre=3.0.0-alpha1 superseded=false",
+		}},
+		{"patch", collection.GroupBy(3), []string{
+			"0.9.0[0.9] stable=0.9 pre=<nil> superseded=true",
+			"1.0.0[1.0.0] stable=1.0.0 pre=<nil> superseded=true",
+			"1.1.0[1.1.0] stable=1.1.0 pre=<nil> superseded=true",
+			"1.1.1[1.1.1 1.1.1-p1 1.1.1.1] stable=1.1.1.1 pre=<nil> superseded=true",
+			"1.2.0[1.2.0-RC1] stable=<nil> pre=1.2.0-RC1 superseded=true",
+			"2.0.0[2.0.0-beta1 2.0.0] stable=2.0.0 pre=2.0.0-beta1 superseded=false",
+			"3.0.0[3.0.0-alpha1] stable=<nil> pre=3.0.0-alpha1 superseded=false",
+		}},
+		{"depth below 1", collection.GroupBy(0), majorLines},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, expected := describeReleaseLines(tt.lines), strings.Join(tt.expected, "\n"); got != expected {
+				t.Errorf("got\n%s\nexpected\n%s", got, expected)
+			}
+		})
+	}
+}
+
+func TestCollectionGroupByFourSegments(t *testing.T) {
+	collection := newCollection(t, "1.2.3.4", "1.2.3", "1.2.3.5-beta1", "1.2.4")
+	lines := collection.GroupBy(4)
+	expected := strings.Join([]string{
+		"1.2.3.0[1.2.3] stable=1.2.3 pre=<nil> superseded=true",
+		"1.2.3.4[1.2.3.4] stable=1.2.3.4 pre=<nil> superseded=true",
+		"1.2.3.5[1.2.3.5-beta1] stable=<nil> pre=1.2.3.5-beta1 superseded=true",
+		"1.2.4.0[1.2.4] stable=1.2.4 pre=<nil> superseded=false",
+	}, "\n")
+	if got := describeReleaseLines(lines); got != expected {
+		t.Errorf("got\n%s\nexpected\n%s", got, expected)
+	}
+}
+
+func TestCollectionGroupByEmpty(t *testing.T) {
+	if lines := newCollection(t, "dev-main", "1.x-dev").GroupByMajor(); len(lines) != 0 {
+		t.Errorf("GroupByMajor() = %s, expected no lines", describeReleaseLines(lines))
+	}
+}
@@ -0,0 +1,170 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// This file holds Selector, which predicts the version Composer installs for
+// a root requirement from the versions a repository offers.
+
+// Selector picks the best candidate for a requirement the way Composer does
+// for a root package: versions less stable than the minimum stability are not
+// considered, and of the rest the highest one wins, or with PreferStable the
+// most stable one and then the highest, as in Composer's
+// VersionSelector::findBestCandidate. The zero value uses Composer's defaults.
+type Selector struct {
+	// MinimumStability is the least stable version to consider: "dev",
+	// "alpha", "beta", "RC" or "stable". Empty means "stable".
+	MinimumStability string
+	// PreferStable picks the most stable candidate before the highest one.
+	PreferStable bool
+}
+
+// RejectReason classifies why Select passed over a version.
+type RejectReason int
+
+const (
+	RejectConstraint   RejectReason = iota // the version does not satisfy the constraint
+	RejectStability                        // the version is less stable than the allowed stability
+	RejectLowerVersion                     // the selected version is higher, or the same version listed earlier
+	RejectLessStable                       // PreferStable picked a more stable version
+)
+
+// String returns a short name for the reason.
+func (r RejectReason) String() string {
+	switch r {
+	case RejectConstraint:
+		return "constraint"
+	case RejectStability:
+		return "stability"
+	case RejectLowerVersion:
+		return "lower version"
+	case RejectLessStable:
+		return "less stable"
+	default:
+		return "RejectReason(" + strconv.Itoa(int(r)) + ")"
+	}
+}
+
+// Rejection is a version Select did not pick, and why.
+type Rejection struct {
+	Version *Version
+	Reason  RejectReason
+	Message string
+}
+
+// Selection is the result of Select. Version is nil when no candidate
+// qualifies. Stability is the least stable stability that was allowed, and
+// Rejected lists every other version in input order.
+type Selection struct {
+	Version   *Version
+	Stability string
+	Rejected  []Rejection
+}
+
+// Select returns the version of versions Composer would install for cs.
+//
+// As for a root requirement, an "@stability" flag in cs replaces the minimum
+// stability, the least stable flag winning when there are several, so
+// "^1.0@dev" allows dev versions and "^1.0@stable" only stable ones. Without
+// a flag, a term naming an unstable version, such as "^2.0-beta1" or
+// "dev-main", lowers the allowed stability to that version's.
+//
+// Select returns an error if MinimumStability is not a known stability.
+func (s Selector) Select(versions Collection, cs Constraints) (Selection, error) {
+	minimum := "stable"
+	if s.MinimumStability != "" {
+		minimum = strings.ToLower(s.MinimumStability)
+		if _, ok := stabilityLevels[minimum]; !ok {
+			return Selection{}, fmt.Errorf("unknown minimum stability: %s", s.MinimumStability)
+		}
+	}
+	allowed := allowedStability(cs, minimum)
+	selection := Selection{Stability: expandStability(allowed)}
+
+	var candidates Collection
+	for _, v := range versions {
+		stability := getVersionStability(v)
+		switch {
+		case !cs.Check(v):
+			selection.Rejected = append(selection.Rejected, Rejection{
+				Version: v,
+				Reason:  RejectConstraint,
+				Message: fmt.Sprintf("%s does not satisfy %s", v.original, cs.Pretty()),
+			})
+		case stabilityLevels[stability] < stabilityLevels[allowed]:
+			selection.Rejected = append(selection.Rejected, Rejection{
+				Version: v,
+				Reason:  RejectStability,
+				Message: fmt.Sprintf("%s is %s, less stable than the allowed stability %s", v.original, expandStability(stability), expandStability(allowed)),
+			})
+		default:
+			candidates = append(candidates, v)
+		}
+	}
+
+	for _, v := range candidates {
+		if selection.Version == nil || s.better(v, selection.Version) {
+			selection.Version = v
+		}
+	}
+	for _, v := range candidates {
+		if v == selection.Version {
+			continue
+		}
+		rejection := Rejection{
+			Version: v,
+			Reason:  RejectLowerVersion,
+			Message: fmt.Sprintf("%s is not higher than %s", v.original, selection.Version.original),
+		}
+		if s.PreferStable && stabilityLevels[getVersionStability(v)] < stabilityLevels[getVersionStability(selection.Version)] {
+			rejection.Reason = RejectLessStable
+			rejection.Message = fmt.Sprintf("%s is less stable than %s", v.original, selection.Version.original)
+		}
+		selection.Rejected = append(selection.Rejected, rejection)
+	}
+	return selection, nil
+}
+
+// better reports whether candidate beats the current best.
+func (s Selector) better(candidate, best *Version) bool {
+	if s.PreferStable {
+		candidateLevel, bestLevel := stabilityLevels[getVersionStability(candidate)], stabilityLevels[getVersionStability(best)]
+		if candidateLevel != bestLevel {
+			return candidateLevel > bestLevel
+		}
+	}
+	return compareForSort(candidate, best) > 0
+}
+
+// allowedStability returns the least stable stability a root requirement of
+// cs accepts, following Composer's RootPackageLoader::extractStabilityFlags.
+func allowedStability(cs Constraints, minimum string) string {
+	flagged := ""
+	for _, group := range cs {
+		for _, c := range group {
+			flag := strings.ToLower(c.Stability())
+			if flag != "" && (flagged == "" || stabilityLevels[flag] < stabilityLevels[flagged]) {
+				flagged = flag
+			}
+		}
+	}
+	if flagged != "" {
+		return flagged
+	}
+
+	allowed := minimum
+	for _, group := range cs {
+		for _, c := range group {
+			if c.check == nil {
+				continue
+			}
+			if stability := getVersionStability(c.check); stabilityLevels[stability] < stabilityLevels[allowed] {
+				allowed = stability
+			}
+		}
+	}
+	return allowed
+}
@@ -0,0 +1,108 @@
+package version
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSelectorSelect(t *testing.T) {
+	available := []string{"1.0.0", "1.1.0", "1.2.0-beta1", "2.0.0-alpha1", "2.0.0-RC1", "dev-main", "1.x-dev"}
+
+	tests := []struct {
+		name       string
+		selector   Selector
+		constraint string
+		expected   string
+		stability  string
+	}{
+		{"defaults", Selector{}, "*", "1.1.0", "stable"},
+		{"minimum beta", Selector{MinimumStability: "beta"}, "^1.0", "1.2.0-beta1", "beta"},
+		{"minimum RC", Selector{MinimumStability: "RC"}, ">=1.0", "2.0.0-RC1", "RC"},
+		{"minimum dev", Selector{MinimumStability: "dev"}, "^1.0", "1.x-dev", "dev"},
+		{"branches rank below releases", Selector{MinimumStability: "dev"}, "*", "2.0.0-RC1", "dev"},
+		{"prefer stable", Selector{MinimumStability: "dev", PreferStable: true}, "^1.0", "1.1.0", "dev"},
+		{"prefer stable without stable", Selector{MinimumStability: "dev", PreferStable: true}, "^2.0", "2.0.0-RC1", "dev"},
+		{"flag lowers stability", Selector{}, "^2.0@alpha", "2.0.0-RC1", "alpha"},
+		{"flag raises stability", Selector{MinimumStability: "dev"}, "^1.0@stable", "1.1.0", "stable"},
+		{"least stable flag wins", Selector{}, "^1.0@stable || ^2.0@beta", "2.0.0-RC1", "beta"},
+		{"unstable version lowers stability", Selector{}, "^2.0-alpha1", "2.0.0-RC1", "alpha"},
+		{"unstable version does not raise stability", Selector{MinimumStability: "dev"}, "^1.2-beta1", "1.x-dev", "dev"},
+		{"branch", Selector{}, "dev-main", "dev-main", "dev"},
+		{"no candidate", Selector{}, "^2.0", "<nil>", "stable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			versions := newCollection(t, available...)
+			selection, err := tt.selector.Select(versions, MustConstraints(NewConstraint(tt.constraint)))
+			if err != nil {
+				t.Fatalf("Select(%q): %v", tt.constraint, err)
+			}
+			if got := versionOriginal(selection.Version); got != tt.expected {
+				t.Errorf("Select(%q) = %s, expected %s", tt.constraint, got, tt.expected)
+			}
+			if selection.Stability != tt.stability {
+				t.Errorf("Select(%q) stability = %s, expected %s", tt.constraint, selection.Stability, tt.stability)
+			}
+			expectedRejected := len(available)
+			if selection.Version != nil {
+				expectedRejected--
+			}
+			if len(selection.Rejected) != expectedRejected {
+				t.Errorf("Select(%q) rejected %d versions, expected %d", tt.constraint, len(selection.Rejected), expectedRejected)
+			}
+		})
+	}
+}
+
+func TestSelectorRejections(t *testing.T) {
+	versions := newCollection(t, "1.0.0", "1.5.0", "2.0.0-beta1", "2.0.0", "3.0.0")
+	selection, err := Selector{MinimumStability: "beta", PreferStable: true}.Select(versions, MustConstraints(NewConstraint("^1.0 || ^2.0")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		version string
+		reason  RejectReason
+		message string
+	}{
+		{"3.0.0", RejectConstraint, "3.0.0 does not satisfy ^1.0 || ^2.0"},
+		{"1.0.0", RejectLowerVersion, "1.0.0 is not higher than 2.0.0"},
+		{"1.5.0", RejectLowerVersion, "1.5.0 is not higher than 2.0.0"},
+		{"2.0.0-beta1", RejectLessStable, "2.0.0-beta1 is less stable than 2.0.0"},
+	}
+	if len(selection.Rejected) != len(expected) {
+		t.Fatalf("Rejected = %v, expected %d rejections", selection.Rejected, len(expected))
+	}
+	for i, want := range expected {
+		got := selection.Rejected[i]
+		if got.Version.Original() != want.version || got.Reason != want.reason || got.Message != want.message {
+			t.Errorf("Rejected[%d] = {%s %s %q}, expected {%s %s %q}", i, got.Version.Original(), got.Reason, got.Message, want.version, want.reason, want.message)
+		}
+	}
+}
+
+func TestSelectorStabilityRejection(t *testing.T) {
+	versions := newCollection(t, "1.0.0-alpha2")
+	selection, err := Selector{}.Select(versions, MustConstraints(NewConstraint("^1.0")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if selection.Version != nil {
+		t.Fatalf("Version = %s, expected nil", selection.Version.Original())
+	}
+	if len(selection.Rejected) != 1 || selection.Rejected[0].Reason != RejectStability {
+		t.Fatalf("Rejected = %v, expected one stability rejection", selection.Rejected)
+	}
+	if got, expected := selection.Rejected[0].Message, "1.0.0-alpha2 is alpha, less stable than the allowed stability stable"; got != expected {
+		t.Errorf("Message = %q, expected %q", got, expected)
+	}
+}
+
+func TestSelectorUnknownStability(t *testing.T) {
+	_, err := Selector{MinimumStability: "nightly"}.Select(nil, MustConstraints(NewConstraint("*")))
+	if err == nil || !strings.Contains(err.Error(), "nightly") {
+		t.Errorf("Select with minimum stability nightly: err = %v, expected unknown stability error", err)
+	}
+}
@@ -0,0 +1,232 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// This file holds the strict SemVer 2.0.0 scheme: parsing that validates
+// against semver.org and precedence as defined by section 11 of the spec.
+
+// Scheme selects the versioning rules a Version is parsed and compared with.
+type Scheme int
+
+const (
+	// Composer is the default scheme: versions are normalized the way
+	// Composer does and prereleases are ranked by stability.
+	Composer Scheme = iota
+	// SemVer2 parses strictly per semver.org and compares with spec
+	// precedence: dot-separated prerelease identifiers are compared one by
+	// one, numeric identifiers numerically and the rest in ASCII order.
+	SemVer2
+)
+
+const (
+	semverIdentifier = `(?:0|[1-9][0-9]*|[0-9]*[A-Za-z-][0-9A-Za-z-]*)`
+	semverSuffix     = `(?:-(` + semverIdentifier + `(?:\.` + semverIdentifier + `)*))?` +
+		`(?:\+([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?$`
+)
+
+var (
+	semverRegexp = regexp.MustCompile(
+		`^(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)` + semverSuffix)
+	// semverConstraintRegexp accepts the partial versions constraints are
+	// written with, such as "^1.2" or "v2".
+	semverConstraintRegexp = regexp.MustCompile(
+		`^[vV]?(0|[1-9][0-9]*)(?:\.(0|[1-9][0-9]*))?(?:\.(0|[1-9][0-9]*))?` + semverSuffix)
+)
+
+// NewSemver parses v as a strict SemVer 2.0.0 version. It is shorthand for
+// NewVersionWithOptions with Scheme SemVer2.
+func NewSemver(v string) (*Version, error) {
+	return parseSemver(v, semverRegexp)
+}
+
+// NewConstraintWithOptions parses a constraint string like NewConstraint. With
+// Scheme SemVer2 the versions in the constraint are parsed as semantic
+// versions (partial cores such as "1.2" are allowed) and Check compares with
+// spec precedence, so ">=1.0.0-alpha.beta" matches "1.0.0-beta" and
+// "^1.2.3" excludes "1.2.3-rc.1".
+func NewConstraintWithOptions(cs string, opts ParseOptions) (Constraints, error) {
+	switch opts.Scheme {
+	case Composer, SemVer2:
+		return newConstraint(cs, opts.Scheme)
+	default:
+		return nil, fmt.Errorf("unknown version scheme: %d", opts.Scheme)
+	}
+}
+
+// Scheme returns the scheme v was parsed with.
+func (v *Version) Scheme() Scheme {
+	return v.scheme
+}
+
+func (s Scheme) String() string {
+	switch s {
+	case Composer:
+		return "composer"
+	case SemVer2:
+		return "semver2"
+	default:
+		return "Scheme(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+func parseSemver(v string, pattern *regexp.Regexp) (*Version, error) {
+	matches := pattern.FindStringSubmatch(v)
+	if matches == nil {
+		return nil, newParseError(ErrMalformedVersion, v, v, "malformed semantic version: "+v)
+	}
+
+	segments := make([]int64, 3)
+	for i, str := range matches[1:4] {
+		if str == "" {
+			continue
+		}
+		val, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return nil, newParseError(ErrMalformedVersion, v, str, fmt.Sprintf("error parsing version: %s", err))
+		}
+		segments[i] = val
+	}
+
+	return &Version{
+		pre:      matches[4],
+		segments: segments,
+		si:       3,
+		original: v,
+		metadata: matches[5],
+		scheme:   SemVer2,
+	}, nil
+}
+
+// compareSemver compares two non-branch versions with SemVer 2.0.0
+// precedence. Build metadata is ignored.
+func compareSemver(v, other *Version) int {
+	for i := 0; i < len(v.segments) || i < len(other.segments); i++ {
+		var lhs, rhs int64
+		if i < len(v.segments) {
+			lhs = v.segments[i]
+		}
+		if i < len(other.segments) {
+			rhs = other.segments[i]
+		}
+		if lhs != rhs {
+			if lhs < rhs {
+				return -1
+			}
+			return 1
+		}
+	}
+	return compareSemverPrerelease(v.pre, other.pre)
+}
+
+func compareSemverPrerelease(pre, other string) int {
+	if pre == other {
+		return 0
+	}
+	// A version without prerelease has higher precedence.
+	if pre == "" {
+		return 1
+	}
+	if other == "" {
+		return -1
+	}
+
+	self := strings.Split(pre, ".")
+	them := strings.Split(other, ".")
+	for i := 0; i < len(self) && i < len(them); i++ {
+		if c := compareSemverIdentifier(self[i], them[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(self) < len(them):
+		return -1
+	case len(self) > len(them):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareSemverIdentifier(a, b string) int {
+	aNumeric, bNumeric := isNumericIdentifier(a), isNumericIdentifier(b)
+	switch {
+	case aNumeric && bNumeric:
+		// Without leading zeros, a longer number is a larger one.
+		a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+		if len(a) != len(b) {
+			if len(a) < len(b) {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	case aNumeric:
+		return -1
+	case bNumeric:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+func isNumericIdentifier(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// checkSemver evaluates c against v with spec precedence. Caret and tilde
+// ranges keep their Composer meaning, but the upper bound is exclusive on the
+// release core, so "^1.2" admits neither 2.0.0 nor 2.0.0-rc.1.
+func (c *Constraint) checkSemver(v *Version) bool {
+	cmp := compareSemver(v, c.check)
+	switch c.operator {
+	case "", "=", "==":
+		return cmp == 0
+	case "!=", "<>":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case "^", "~":
+		return cmp >= 0 && comparePrefix(v.segments, c.semverUpperBound()) < 0
+	default:
+		return c.f(v, c.check, c.origSegments)
+	}
+}
+
+// semverUpperBound returns the exclusive release core a caret or tilde
+// constraint ends at, mirroring constraintCaret and constraintTilde.
+func (c *Constraint) semverUpperBound() []int64 {
+	s := c.check.segments
+	if c.operator == "~" {
+		if c.origSegments >= 3 {
+			return []int64{s[0], s[1] + 1}
+		}
+		return []int64{s[0] + 1}
+	}
+
+	switch {
+	case s[0] != 0:
+		return []int64{s[0] + 1}
+	case c.origSegments <= 1:
+		return []int64{1}
+	case s[1] != 0 || c.origSegments <= 2:
+		return []int64{0, s[1] + 1}
+	default:
+		return []int64{0, 0, s[2] + 1}
+	}
+}
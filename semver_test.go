@@ -0,0 +1,169 @@
+package version
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewSemver(t *testing.T) {
+	tests := []struct {
+		version string
+		err     bool
+	}{
+		{"1.0.0", false},
+		{"0.0.0", false},
+		{"1.0.0-alpha", false},
+		{"1.0.0-alpha.1", false},
+		{"1.0.0-0.3.7", false},
+		{"1.0.0-x.7.z.92", false},
+		{"1.0.0-x-y-z.--", false},
+		{"1.0.0-alpha+001", false},
+		{"1.0.0+20130313144700", false},
+		{"1.0.0-beta+exp.sha.5114f85", false},
+		{"1.2", true},
+		{"1.2.3.4", true},
+		{"v1.2.3", true},
+		{"01.2.3", true},
+		{"1.2.3-01", true},
+		{"1.2.3-", true},
+		{"1.2.3-alpha..1", true},
+		{"1.2.3+", true},
+		{"1.2.3-b@1", true},
+		{" 1.2.3", true},
+		{"dev-main", true},
+	}
+
+	for _, tc := range tests {
+		v, err := NewSemver(tc.version)
+		if tc.err {
+			if !errors.Is(err, ErrMalformedVersion) {
+				t.Errorf("%q: expected ErrMalformedVersion, got %v", tc.version, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", tc.version, err)
+			continue
+		}
+		if v.Scheme() != SemVer2 {
+			t.Errorf("%q: expected scheme semver2, got %s", tc.version, v.Scheme())
+		}
+		if v.Original() != tc.version {
+			t.Errorf("%q: expected original to be kept, got %q", tc.version, v.Original())
+		}
+	}
+}
+
+func TestSemverParts(t *testing.T) {
+	v := Must(NewSemver("1.0.0-beta.11+exp.sha.5114f85"))
+	if v.Prerelease() != "beta.11" {
+		t.Errorf("expected prerelease beta.11, got %q", v.Prerelease())
+	}
+	if v.Metadata() != "exp.sha.5114f85" {
+		t.Errorf("expected metadata exp.sha.5114f85, got %q", v.Metadata())
+	}
+	if v.NormalizedString() != "1.0.0-beta.11" {
+		t.Errorf("expected normalized 1.0.0-beta.11, got %q", v.NormalizedString())
+	}
+}
+
+func TestSemverPrecedence(t *testing.T) {
+	// The precedence example from section 11 of the spec, in ascending order.
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"2.0.0",
+		"2.1.0",
+		"2.1.1",
+	}
+
+	for i, left := range ordered {
+		for j, right := range ordered {
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+			actual := Must(NewSemver(left)).Compare(Must(NewSemver(right)))
+			if actual != expected {
+				t.Errorf("%s vs %s: expected %d, got %d", left, right, expected, actual)
+			}
+		}
+	}
+
+	if !Must(NewSemver("1.0.0+build.1")).Equal(Must(NewSemver("1.0.0+build.2"))) {
+		t.Error("build metadata must not affect precedence")
+	}
+}
+
+func TestNewVersionWithSchemeOption(t *testing.T) {
+	v, err := NewVersionWithOptions("1.0.0-alpha.beta", ParseOptions{Scheme: SemVer2})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if v.Scheme() != SemVer2 {
+		t.Errorf("expected scheme semver2, got %s", v.Scheme())
+	}
+
+	v, err = NewVersionWithOptions("1.0-b1", ParseOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if v.Scheme() != Composer || v.NormalizedString() != "1.0.0.0-beta1" {
+		t.Errorf("expected Composer parsing, got %s (%s)", v.NormalizedString(), v.Scheme())
+	}
+
+	if _, err := NewVersionWithOptions("1.0.0", ParseOptions{Scheme: Scheme(42)}); err == nil {
+		t.Error("expected error for unknown scheme")
+	}
+}
+
+func TestSemverConstraintCheck(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		check      bool
+	}{
+		{">=1.0.0-alpha.beta", "1.0.0-beta", true},
+		{">=1.0.0-alpha.beta", "1.0.0-alpha.1", false},
+		{">1.0.0-beta.2", "1.0.0-beta.11", true},
+		{">=1.0.0", "1.0.0-rc.1", false},
+		{"<1.0.0", "1.0.0-rc.1", true},
+		{"1.0.0", "1.0.0+build.5", true},
+		{"!=1.0.0-rc.1", "1.0.0-rc.1", false},
+		{"^1.2", "1.9.0", true},
+		{"^1.2", "2.0.0-rc.1", false},
+		{"^1.2.3", "1.2.3-rc.1", false},
+		{"^0.3", "0.3.9", true},
+		{"^0.3", "0.4.0", false},
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{"~1.2", "1.9.0", true},
+		{"1.0.0 - 2.0.0", "2.0.0", true},
+		{"1.0.0 - 2.0.0", "2.0.1", false},
+		{"1.2.*", "1.2.7", true},
+		{">=1.0.0-rc.1 || ^2.0", "2.3.0", true},
+	}
+
+	for _, tc := range tests {
+		cs, err := NewConstraintWithOptions(tc.constraint, ParseOptions{Scheme: SemVer2})
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", tc.constraint, err)
+			continue
+		}
+		if actual := cs.Check(Must(NewSemver(tc.version))); actual != tc.check {
+			t.Errorf("%q vs %s: expected %t, got %t", tc.constraint, tc.version, tc.check, actual)
+		}
+	}
+
+	if _, err := NewConstraintWithOptions(">=1.0.0.0", ParseOptions{Scheme: SemVer2}); !errors.Is(err, ErrMalformedVersion) {
+		t.Errorf("expected ErrMalformedVersion for a four-segment version, got %v", err)
+	}
+}
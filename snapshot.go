@@ -1,8 +1,8 @@
 package version
 
 import (
-	"fmt"
 	"sort"
+	"strings"
 )
 
 // This file holds the snapshot layer: serializable representations of domains,
@@ -59,25 +59,11 @@ func constraintBoundsSnapshot(operator, version string) (boundSnapshot, boundSna
 }
 
 func domainUnionBoundsSnapshot(domains []constraintDomain) (boundSnapshot, boundSnapshot) {
-	intervals := unionNumericIntervals(domains)
-	if len(intervals) == 0 {
+	lower, upper, ok := unionBounds(domains)
+	if !ok {
 		return zeroBoundSnapshot(), positiveInfinityBoundSnapshot()
 	}
 
-	var lower *versionBound
-	var upper *versionBound
-	initialized := false
-	for _, interval := range intervals {
-		if !initialized {
-			lower = interval.lower
-			upper = interval.upper
-			initialized = true
-			continue
-		}
-		lower = minLowerBound(lower, interval.lower)
-		upper = maxUpper(upper, interval.upper)
-	}
-
 	return snapshotLowerBound(lower), snapshotUpperBound(upper)
 }
 
@@ -91,7 +77,7 @@ func combineRawConstraintBoundsSnapshot(parts []string, conjunctive bool) (bound
 	for i, part := range parts {
 		operator, version, _, ok := splitConstraintParts(part)
 		if !ok {
-			return boundSnapshot{}, boundSnapshot{}, fmt.Errorf("malformed constraint: %s", part)
+			return boundSnapshot{}, boundSnapshot{}, malformedConstraint(part, strings.TrimSpace(part))
 		}
 		partLower, partUpper, err := constraintBoundsSnapshot(operator, version)
 		if err != nil {
@@ -209,6 +195,24 @@ func domainUnionSnapshot(domains []constraintDomain) domainSnapshot {
 }
 
 func snapshotNumericIntervals(intervals []versionInterval) []intervalSnapshot {
+	merged := mergeNumericIntervals(intervals)
+	if len(merged) == 0 {
+		return nil
+	}
+
+	snapshots := make([]intervalSnapshot, 0, len(merged))
+	for _, interval := range merged {
+		snapshots = append(snapshots, intervalSnapshot{
+			Start: formatLowerBound(interval.lower),
+			End:   formatUpperBound(interval.upper),
+		})
+	}
+	return snapshots
+}
+
+// mergeNumericIntervals splits intervals at their exclusions and merges the
+// pieces into sorted, disjoint intervals without exclusions.
+func mergeNumericIntervals(intervals []versionInterval) []versionInterval {
 	segments := splitIntervalExclusions(intervals)
 	if len(segments) == 0 {
 		return nil
@@ -234,15 +238,7 @@ func snapshotNumericIntervals(intervals []versionInterval) []intervalSnapshot {
 		}
 		merged = append(merged, interval)
 	}
-
-	snapshots := make([]intervalSnapshot, 0, len(merged))
-	for _, interval := range merged {
-		snapshots = append(snapshots, intervalSnapshot{
-			Start: formatLowerBound(interval.lower),
-			End:   formatUpperBound(interval.upper),
-		})
-	}
-	return snapshots
+	return merged
 }
 
 func splitIntervalExclusions(intervals []versionInterval) []versionInterval {
@@ -0,0 +1,373 @@
+package version
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// This file holds the order-preserving sort key encoding: a printable-ASCII
+// byte string whose bytewise order matches Version.Compare, suitable for range
+// scans in key-value stores and binary-collated text columns.
+//
+// Layout of a numeric version key:
+//
+//	'2' segment* '-' prerelease-part* 'f'
+//
+// Each segment is a length byte ('a' for one digit, 'b' for two, ...) followed
+// by its decimal digits; trailing zero segments are dropped so 1.2 and 1.2.0.0
+// share a key. Each prerelease part is a rank byte (see sortKeyRanks), an
+// encoded suffix and a '!' terminator. The final 'f' sits above the dev, alpha,
+// beta and RC ranks and below numeric parts, so 1.0-beta < 1.0 < 1.0-patch1
+// and beta < beta.1, mirroring comparePrereleases.
+//
+// Branch versions (dev-*) encode as '1' followed by the branch name. Following
+// Compare, they sort before every numeric version and among themselves by
+// name. Collection's promotion of dev-master, dev-trunk and dev-default to the
+// newest position is a sorting convention and is not reflected in the key.
+//
+// Versions of the SemVer2 scheme follow spec precedence instead:
+//
+//	'3' segment* '-' ( identifier+ 'a' | 'f' )
+//
+// Numeric identifiers are 'b' and a length-prefixed number, the others 'c',
+// their bytes and a '!' terminator. The 'a' after the last identifier sorts
+// a shorter prerelease first, and a release is the lone 'f' above them.
+
+const (
+	sortKeyBranch        = '1'
+	sortKeyNumeric       = '2'
+	sortKeySemver        = '3'
+	sortKeySegmentEnd    = '-'
+	sortKeyPartEnd       = '!'
+	sortKeyPrereleaseEnd = 'f'
+
+	// Prerelease identifiers of SemVer2 keys.
+	sortKeySemverEnd          = 'a'
+	sortKeySemverNumeric      = 'b'
+	sortKeySemverAlphanumeric = 'c'
+
+	// Numbers are written as a length byte relative to these bases followed by
+	// their digits: segments use 'a'..'s', prerelease suffixes '1'..'C'.
+	sortKeySegmentBase = '`'
+	sortKeySuffixBase  = '0'
+)
+
+// sortKeyRanks maps prerelease ranks to their key byte. The gap at 'f' is
+// taken by sortKeyPrereleaseEnd.
+var sortKeyRanks = map[int]byte{
+	prereleaseRankDev:    'b',
+	prereleaseRankAlpha:  'c',
+	prereleaseRankBeta:   'd',
+	prereleaseRankRC:     'e',
+	prereleaseRankStable: 'g',
+	prereleaseRankPatch:  'h',
+	prereleaseRankOther:  'i',
+}
+
+// sortKeyRankNames is the canonical prefix DecodeSortKey emits for each rank.
+var sortKeyRankNames = map[byte]string{
+	'b': "dev",
+	'c': "alpha",
+	'd': "beta",
+	'e': "RC",
+	'g': "",
+	'h': "patch",
+}
+
+// SortKey returns a byte string whose bytewise order matches Compare for
+// versions of the Composer scheme: for any two such versions a and b,
+// bytes.Compare(a.SortKey(), b.SortKey()) has the same sign as a.Compare(b),
+// and versions that compare equal share a key.
+//
+// Numeric segments compare numerically, prereleases follow the ranked order
+// dev < alpha < beta < RC < stable < patch, and numeric suffixes compare
+// numerically (rc2 < rc10). Branch versions (dev-*) sort before all numeric
+// versions, ordered by branch name.
+//
+// Compare is not transitive for a few exotic prereleases that mix numeric and
+// textual suffixes (rc2 vs rc10-dev) or end in a textual dot-part (beta.x vs
+// beta); for those the key orders suffixes by their leading number and sorts
+// the shorter prerelease first, which keeps the key a total order.
+//
+// Versions of the SemVer2 scheme get keys in spec precedence, so 1.0.0-RC.1
+// sorts before 1.0.0-alpha as it does by Compare. Their keys sort after all
+// Composer keys; compare keys of one scheme only.
+//
+// The key is printable ASCII for numeric versions; branch keys carry the
+// branch name verbatim.
+func (v *Version) SortKey() []byte {
+	if v.branch != "" {
+		return append([]byte{sortKeyBranch}, v.branch...)
+	}
+	if v.scheme == SemVer2 {
+		return v.semverSortKey()
+	}
+
+	key := appendSortKeySegments([]byte{sortKeyNumeric}, v.segments)
+	if v.pre != "" {
+		for _, part := range strings.Split(v.pre, ".") {
+			key = appendSortKeyPart(key, part)
+		}
+	}
+	return append(key, sortKeyPrereleaseEnd)
+}
+
+func (v *Version) semverSortKey() []byte {
+	key := appendSortKeySegments([]byte{sortKeySemver}, v.segments)
+	if v.pre == "" {
+		return append(key, sortKeyPrereleaseEnd)
+	}
+	for _, identifier := range strings.Split(v.pre, ".") {
+		if isNumericIdentifier(identifier) {
+			key = append(key, sortKeySemverNumeric, sortKeySuffixBase+byte(len(identifier)))
+			key = append(key, identifier...)
+			continue
+		}
+		key = append(key, sortKeySemverAlphanumeric)
+		key = append(key, identifier...)
+		key = append(key, sortKeyPartEnd)
+	}
+	return append(key, sortKeySemverEnd)
+}
+
+// appendSortKeySegments appends segments without their trailing zeros and the
+// segment terminator.
+func appendSortKeySegments(key []byte, segments []int64) []byte {
+	for len(segments) > 0 && segments[len(segments)-1] == 0 {
+		segments = segments[:len(segments)-1]
+	}
+	for _, segment := range segments {
+		key = appendSortKeyNumber(key, sortKeySegmentBase, segment)
+	}
+	return append(key, sortKeySegmentEnd)
+}
+
+// DecodeSortKey reverses SortKey. The returned version compares equal to the
+// one the key was built from; its Original() is the canonical form, so
+// spelling details such as leading zeros or a "v" prefix are not recovered.
+func DecodeSortKey(key []byte) (*Version, error) {
+	if len(key) < 2 {
+		return nil, fmt.Errorf("malformed sort key: %q", key)
+	}
+
+	switch key[0] {
+	case sortKeyBranch:
+		branch := string(key[1:])
+		return &Version{
+			pre:      "dev",
+			segments: []int64{0, 0, 0},
+			original: branch,
+			branch:   branch,
+		}, nil
+	case sortKeyNumeric:
+		return decodeNumericSortKey(key)
+	case sortKeySemver:
+		return decodeSemverSortKey(key)
+	default:
+		return nil, fmt.Errorf("malformed sort key: %q", key)
+	}
+}
+
+func decodeNumericSortKey(key []byte) (*Version, error) {
+	segments, rest, ok := readSortKeySegments(key[1:])
+	if !ok || len(rest) == 0 || rest[len(rest)-1] != sortKeyPrereleaseEnd {
+		return nil, fmt.Errorf("malformed sort key: %q", key)
+	}
+	rest = rest[1 : len(rest)-1]
+
+	var parts []string
+	for len(rest) > 0 {
+		end := bytes.IndexByte(rest, sortKeyPartEnd)
+		if end < 0 {
+			return nil, fmt.Errorf("malformed sort key: %q", key)
+		}
+		part, ok := decodeSortKeyPart(rest[:end])
+		if !ok {
+			return nil, fmt.Errorf("malformed sort key: %q", key)
+		}
+		parts = append(parts, part)
+		rest = rest[end+1:]
+	}
+
+	for len(segments) < 4 {
+		segments = append(segments, 0)
+	}
+	formatted := make([]string, len(segments))
+	for i, segment := range segments {
+		formatted[i] = strconv.FormatInt(segment, 10)
+	}
+	pre := strings.Join(parts, ".")
+	original := strings.Join(formatted, ".")
+	if pre != "" {
+		original += "-" + pre
+	}
+
+	return &Version{
+		pre:      pre,
+		segments: segments,
+		si:       len(segments),
+		original: original,
+	}, nil
+}
+
+func decodeSemverSortKey(key []byte) (*Version, error) {
+	segments, rest, ok := readSortKeySegments(key[1:])
+	if !ok || len(rest) < 2 || len(segments) > 3 {
+		return nil, fmt.Errorf("malformed sort key: %q", key)
+	}
+	for len(segments) < 3 {
+		segments = append(segments, 0)
+	}
+
+	var identifiers []string
+	rest = rest[1:]
+	if len(rest) != 1 || rest[0] != sortKeyPrereleaseEnd {
+		for len(rest) > 0 && rest[0] != sortKeySemverEnd {
+			identifier, remaining, ok := readSemverSortKeyIdentifier(rest)
+			if !ok {
+				return nil, fmt.Errorf("malformed sort key: %q", key)
+			}
+			identifiers = append(identifiers, identifier)
+			rest = remaining
+		}
+		if len(identifiers) == 0 || len(rest) != 1 {
+			return nil, fmt.Errorf("malformed sort key: %q", key)
+		}
+	}
+
+	formatted := make([]string, len(segments))
+	for i, segment := range segments {
+		formatted[i] = strconv.FormatInt(segment, 10)
+	}
+	pre := strings.Join(identifiers, ".")
+	original := strings.Join(formatted, ".")
+	if pre != "" {
+		original += "-" + pre
+	}
+
+	return &Version{
+		pre:      pre,
+		segments: segments,
+		si:       len(segments),
+		original: original,
+		scheme:   SemVer2,
+	}, nil
+}
+
+func readSemverSortKeyIdentifier(encoded []byte) (string, []byte, bool) {
+	switch encoded[0] {
+	case sortKeySemverNumeric:
+		if len(encoded) < 2 || encoded[1] <= sortKeySuffixBase {
+			return "", nil, false
+		}
+		end := 2 + int(encoded[1]-sortKeySuffixBase)
+		if end > len(encoded) || !isNumericIdentifier(string(encoded[2:end])) {
+			return "", nil, false
+		}
+		return string(encoded[2:end]), encoded[end:], true
+	case sortKeySemverAlphanumeric:
+		end := bytes.IndexByte(encoded, sortKeyPartEnd)
+		if end < 2 {
+			return "", nil, false
+		}
+		return string(encoded[1:end]), encoded[end+1:], true
+	default:
+		return "", nil, false
+	}
+}
+
+// readSortKeySegments reads the segments of a numeric key up to and
+// including the segment terminator.
+func readSortKeySegments(encoded []byte) ([]int64, []byte, bool) {
+	var segments []int64
+	for len(encoded) > 0 && encoded[0] != sortKeySegmentEnd {
+		segment, remaining, ok := readSortKeyNumber(encoded, sortKeySegmentBase)
+		if !ok {
+			return nil, nil, false
+		}
+		segments = append(segments, segment)
+		encoded = remaining
+	}
+	return segments, encoded, len(encoded) > 0
+}
+
+func appendSortKeyPart(key []byte, part string) []byte {
+	parsed := parsePrereleasePart(part)
+	key = append(key, sortKeyRanks[parsed.rank])
+	if parsed.rank == prereleaseRankOther {
+		key = append(key, part...)
+		return append(key, sortKeyPartEnd)
+	}
+
+	suffix := parsed.suffix
+	digits := 0
+	for digits < len(suffix) && suffix[digits] >= '0' && suffix[digits] <= '9' {
+		digits++
+	}
+	if digits > 0 {
+		if value, err := strconv.ParseInt(suffix[:digits], 10, 64); err == nil {
+			key = appendSortKeyNumber(key, sortKeySuffixBase, value)
+			suffix = suffix[digits:]
+		}
+	}
+	key = append(key, suffix...)
+	return append(key, sortKeyPartEnd)
+}
+
+func decodeSortKeyPart(encoded []byte) (string, bool) {
+	if len(encoded) == 0 {
+		return "", false
+	}
+	rank := encoded[0]
+	if rank == sortKeyRanks[prereleaseRankOther] {
+		return string(encoded[1:]), len(encoded) > 1
+	}
+	name, ok := sortKeyRankNames[rank]
+	if !ok {
+		return "", false
+	}
+
+	rest := encoded[1:]
+	suffix := ""
+	if len(rest) > 0 && rest[0] > sortKeySuffixBase && rest[0] <= sortKeySuffixBase+19 {
+		value, remaining, ok := readSortKeyNumber(rest, sortKeySuffixBase)
+		if !ok {
+			return "", false
+		}
+		suffix = strconv.FormatInt(value, 10)
+		rest = remaining
+	}
+	suffix += string(rest)
+
+	if name == "" && (suffix == "" || suffix[0] < '0' || suffix[0] > '9') {
+		// Only purely numeric parts drop the "stable" prefix on the way back.
+		name = "stable"
+	}
+	return name + suffix, true
+}
+
+// appendSortKeyNumber appends value as a length byte (base+1 for one digit,
+// base+2 for two, ...) followed by its decimal digits, so that shorter numbers
+// sort first and equal-length numbers sort by their digits.
+func appendSortKeyNumber(key []byte, base byte, value int64) []byte {
+	digits := strconv.FormatInt(value, 10)
+	key = append(key, base+byte(len(digits)))
+	return append(key, digits...)
+}
+
+func readSortKeyNumber(encoded []byte, base byte) (int64, []byte, bool) {
+	if len(encoded) == 0 || encoded[0] <= base || encoded[0] > base+19 {
+		return 0, nil, false
+	}
+	length := int(encoded[0] - base)
+	if len(encoded) < 1+length {
+		return 0, nil, false
+	}
+	value, err := strconv.ParseInt(string(encoded[1:1+length]), 10, 64)
+	if err != nil {
+		return 0, nil, false
+	}
+	return value, encoded[1+length:], true
+}
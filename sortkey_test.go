@@ -0,0 +1,157 @@
+package version
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+)
+
+func TestSortKeyMatchesCompare(t *testing.T) {
+	versions := []string{
+		"dev-feature", "dev-main", "dev-master",
+		"0.0.1", "0.1", "1.0.0-dev", "1.0.0-alpha", "1.0.0-alpha1", "1.0.0-alpha2",
+		"1.0.0-beta", "1.0.0-beta1", "1.0.0-beta1.1", "1.0.0-beta2", "1.0.0-beta-dev",
+		"1.0.0-RC1", "1.0.0-rc2", "1.0.0-RC10", "1", "1.0", "1.0.0", "v1.0.0.0",
+		"1.0.0-patch1", "1.0.0-pl2", "1.0.1", "1.0.10", "1.2.3.4", "1.10.0",
+		"2.1.x-dev", "2.1.0", "10.0.0", "20100102", "2010.01.02",
+	}
+
+	for _, left := range versions {
+		for _, right := range versions {
+			a := Must(NewVersion(left))
+			b := Must(NewVersion(right))
+			expected := a.Compare(b)
+			actual := bytes.Compare(a.SortKey(), b.SortKey())
+			if expected != actual {
+				t.Errorf("%s vs %s: Compare = %d, SortKey order = %d (%q vs %q)", left, right, expected, actual, a.SortKey(), b.SortKey())
+			}
+		}
+	}
+}
+
+func TestSortKeyOrder(t *testing.T) {
+	ordered := []string{
+		"dev-a", "dev-b",
+		"1.0.0-dev", "1.0.0-alpha1", "1.0.0-beta2", "1.0.0-RC2", "1.0.0-RC10",
+		"1.0.0", "1.0.0-patch1", "1.0.1", "1.2.0", "1.10.0", "2.0.0",
+	}
+
+	keys := make([][]byte, len(ordered))
+	for i, raw := range ordered {
+		keys[i] = Must(NewVersion(raw)).SortKey()
+	}
+	shuffled := append([][]byte{}, keys...)
+	sort.Slice(shuffled, func(i, j int) bool { return bytes.Compare(shuffled[i], shuffled[j]) > 0 })
+	sort.Slice(shuffled, func(i, j int) bool { return bytes.Compare(shuffled[i], shuffled[j]) < 0 })
+
+	for i := range keys {
+		if !bytes.Equal(keys[i], shuffled[i]) {
+			t.Errorf("position %d: expected key of %s, got %q", i, ordered[i], shuffled[i])
+		}
+	}
+}
+
+func TestSortKeyEqualVersionsShareKey(t *testing.T) {
+	tests := [][2]string{
+		{"1.0", "1.0.0.0"},
+		{"v1.2.3", "1.2.3"},
+		{"1.0.0-rc1", "1.0.0-RC1"},
+		{"1.0.0b2", "1.0.0-beta2"},
+	}
+
+	for _, tc := range tests {
+		left := Must(NewVersion(tc[0])).SortKey()
+		right := Must(NewVersion(tc[1])).SortKey()
+		if !bytes.Equal(left, right) {
+			t.Errorf("%s and %s: expected equal keys, got %q and %q", tc[0], tc[1], left, right)
+		}
+	}
+}
+
+func TestSortKeySemverFollowsCompare(t *testing.T) {
+	// Ascending spec precedence, from the example in section 11 of semver.org
+	// plus identifiers Composer ranks differently.
+	ordered := []string{
+		"1.0.0-RC.1",
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.2",
+		"1.0.0-alpha.10",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-beta9",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1-0",
+		"1.0.1",
+		"2.0.0",
+	}
+
+	for i := 0; i+1 < len(ordered); i++ {
+		lower, higher := Must(NewSemver(ordered[i])), Must(NewSemver(ordered[i+1]))
+		if lower.Compare(higher) >= 0 {
+			t.Fatalf("Compare: expected %s < %s", ordered[i], ordered[i+1])
+		}
+		if bytes.Compare(lower.SortKey(), higher.SortKey()) >= 0 {
+			t.Errorf("SortKey: expected %s < %s, got %q and %q", ordered[i], ordered[i+1], lower.SortKey(), higher.SortKey())
+		}
+	}
+
+	if !bytes.Equal(Must(NewSemver("1.0.0+build.1")).SortKey(), Must(NewSemver("1.0.0")).SortKey()) {
+		t.Error("SortKey: expected build metadata to be ignored")
+	}
+
+	for _, raw := range ordered {
+		v := Must(NewSemver(raw))
+		decoded, err := DecodeSortKey(v.SortKey())
+		if err != nil {
+			t.Errorf("DecodeSortKey(%q) unexpected error: %v", v.SortKey(), err)
+			continue
+		}
+		if decoded.Scheme() != SemVer2 || decoded.Original() != raw {
+			t.Errorf("DecodeSortKey(%q): expected SemVer2 %s, got %s %s", v.SortKey(), raw, decoded.Scheme(), decoded.Original())
+		}
+	}
+}
+
+func TestDecodeSortKey(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"v1.2", "1.2.0.0"},
+		{"1.0.0-beta2", "1.0.0.0-beta2"},
+		{"1.0.0-rc10", "1.0.0.0-RC10"},
+		{"1.0.0-beta1.1", "1.0.0.0-beta1.1"},
+		{"1.0.0-beta-dev", "1.0.0.0-beta-dev"},
+		{"1.0.0-patch3", "1.0.0.0-patch3"},
+		{"2.1.x-dev", "2.1.9999999.9999999-dev"},
+		{"20100102", "20100102.0.0.0"},
+		{"dev-feature/x", "dev-feature/x"},
+	}
+
+	for _, tc := range tests {
+		v := Must(NewVersion(tc.input))
+		decoded, err := DecodeSortKey(v.SortKey())
+		if err != nil {
+			t.Errorf("DecodeSortKey(%q) unexpected error: %v", v.SortKey(), err)
+			continue
+		}
+		if decoded.Compare(v) != 0 {
+			t.Errorf("DecodeSortKey(%q): %s does not compare equal to %s", v.SortKey(), decoded.NormalizedString(), tc.input)
+		}
+		if decoded.NormalizedString() != tc.expected {
+			t.Errorf("DecodeSortKey(%q): expected %s, got %s", v.SortKey(), tc.expected, decoded.NormalizedString())
+		}
+	}
+}
+
+func TestDecodeSortKeyErrors(t *testing.T) {
+	for _, key := range []string{"", "2", "x1.0", "2b1-f", "2a1-dz", "2a1-z!f", "3a1-", "3a1-a", "3a1-cx!", "3a1-b1xa", "3a1a1a1a1-f"} {
+		if _, err := DecodeSortKey([]byte(key)); err == nil {
+			t.Errorf("DecodeSortKey(%q): expected error", key)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package version
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// This file holds the database/sql support for Version and Constraints:
+// sql.Scanner and driver.Valuer implementations plus the NullVersion and
+// NullConstraints wrappers for nullable columns.
+
+// ScanError is returned when a database value cannot be scanned into a
+// Version or Constraints, either because the column holds an unsupported type
+// (or NULL for a non-nullable target) or because its text failed to parse. Err
+// holds the underlying parse error, if any.
+type ScanError struct {
+	Target string // "Version" or "Constraints"
+	Value  any
+	Err    error
+}
+
+func (e *ScanError) Error() string {
+	if e.Value == nil {
+		return fmt.Sprintf("cannot scan NULL into %s", e.Target)
+	}
+	if e.Err == nil {
+		return fmt.Sprintf("cannot scan %T into %s", e.Value, e.Target)
+	}
+	return fmt.Sprintf("cannot scan %q into %s: %v", e.Value, e.Target, e.Err)
+}
+
+func (e *ScanError) Unwrap() error {
+	return e.Err
+}
+
+// NullVersion represents a Version that may be NULL. It implements
+// sql.Scanner and driver.Valuer like sql.NullString.
+type NullVersion struct {
+	Version *Version
+	Valid   bool // Valid is true if Version is not NULL
+}
+
+// NullConstraints represents Constraints that may be NULL. It implements
+// sql.Scanner and driver.Valuer like sql.NullString.
+type NullConstraints struct {
+	Constraints Constraints
+	Valid       bool // Valid is true if Constraints is not NULL
+}
+
+// Scan implements sql.Scanner. The column must hold a string or []byte; it is
+// parsed with NewVersion.
+func (v *Version) Scan(src any) error {
+	parsed, err := scanVersion(src)
+	if err != nil {
+		return err
+	}
+	*v = *parsed
+	return nil
+}
+
+// Value implements driver.Valuer, storing Original(). A nil version is stored
+// as NULL.
+func (v *Version) Value() (driver.Value, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return v.original, nil
+}
+
+// Scan implements sql.Scanner like Version.Scan. A NULL column leaves the
+// wrapper empty, mirroring Value.
+func (v *NormalizedVersion) Scan(src any) error {
+	if src == nil {
+		v.Version = nil
+		return nil
+	}
+	parsed, err := scanVersion(src)
+	if err != nil {
+		return err
+	}
+	v.Version = parsed
+	return nil
+}
+
+// Value implements driver.Valuer, storing NormalizedString(). An empty
+// wrapper is stored as NULL.
+func (v NormalizedVersion) Value() (driver.Value, error) {
+	if v.Version == nil {
+		return nil, nil
+	}
+	return v.NormalizedString(), nil
+}
+
+// Scan implements sql.Scanner. The column must hold a string or []byte; it is
+// parsed with NewConstraint. An empty string yields empty constraints.
+func (cs *Constraints) Scan(src any) error {
+	raw, err := scanString(src, "Constraints")
+	if err != nil {
+		return err
+	}
+	parsed, err := unmarshalConstraintsString(raw)
+	if err != nil {
+		return &ScanError{Target: "Constraints", Value: raw, Err: err}
+	}
+	*cs = parsed
+	return nil
+}
+
+// Value implements driver.Valuer, storing the same string MarshalText
+// produces.
+func (cs Constraints) Value() (driver.Value, error) {
+	return cs.String(), nil
+}
+
+// Scan implements sql.Scanner.
+func (n *NullVersion) Scan(src any) error {
+	if src == nil {
+		n.Version, n.Valid = nil, false
+		return nil
+	}
+	var v Version
+	if err := v.Scan(src); err != nil {
+		return err
+	}
+	n.Version, n.Valid = &v, true
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (n NullVersion) Value() (driver.Value, error) {
+	if !n.Valid || n.Version == nil {
+		return nil, nil
+	}
+	return n.Version.original, nil
+}
+
+// Scan implements sql.Scanner.
+func (n *NullConstraints) Scan(src any) error {
+	if src == nil {
+		n.Constraints, n.Valid = nil, false
+		return nil
+	}
+	var cs Constraints
+	if err := cs.Scan(src); err != nil {
+		return err
+	}
+	n.Constraints, n.Valid = cs, true
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (n NullConstraints) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Constraints.String(), nil
+}
+
+func scanVersion(src any) (*Version, error) {
+	raw, err := scanString(src, "Version")
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := NewVersion(raw)
+	if err != nil {
+		return nil, &ScanError{Target: "Version", Value: raw, Err: err}
+	}
+	return parsed, nil
+}
+
+func scanString(src any, target string) (string, error) {
+	switch value := src.(type) {
+	case string:
+		return value, nil
+	case []byte:
+		return string(value), nil
+	default:
+		return "", &ScanError{Target: target, Value: src}
+	}
+}
@@ -0,0 +1,200 @@
+package version
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+)
+
+var (
+	_ sql.Scanner   = (*Version)(nil)
+	_ driver.Valuer = (*Version)(nil)
+	_ sql.Scanner   = (*Constraints)(nil)
+	_ driver.Valuer = Constraints(nil)
+	_ sql.Scanner   = (*NormalizedVersion)(nil)
+	_ driver.Valuer = NormalizedVersion{}
+	_ sql.Scanner   = (*NullVersion)(nil)
+	_ driver.Valuer = NullVersion{}
+	_ sql.Scanner   = (*NullConstraints)(nil)
+	_ driver.Valuer = NullConstraints{}
+)
+
+func TestVersionScanValue(t *testing.T) {
+	for _, src := range []any{"v1.2.3-beta1", []byte("v1.2.3-beta1")} {
+		var v Version
+		if err := v.Scan(src); err != nil {
+			t.Fatalf("Scan(%v) unexpected error: %v", src, err)
+		}
+		if v.NormalizedString() != "1.2.3.0-beta1" {
+			t.Errorf("Scan(%v): expected 1.2.3.0-beta1, got %s", src, v.NormalizedString())
+		}
+		value, err := v.Value()
+		if err != nil {
+			t.Fatalf("Value() unexpected error: %v", err)
+		}
+		if value != "v1.2.3-beta1" {
+			t.Errorf("Value(): expected v1.2.3-beta1, got %v", value)
+		}
+	}
+
+	var nilVersion *Version
+	if value, err := nilVersion.Value(); value != nil || err != nil {
+		t.Errorf("nil Value(): expected (nil, nil), got (%v, %v)", value, err)
+	}
+}
+
+func TestVersionScanErrors(t *testing.T) {
+	tests := []struct {
+		src      any
+		parseErr bool
+	}{
+		{nil, false},
+		{42, false},
+		{"not a version", true},
+	}
+
+	for _, tc := range tests {
+		var v Version
+		err := v.Scan(tc.src)
+		var scanErr *ScanError
+		if !errors.As(err, &scanErr) {
+			t.Errorf("Scan(%v): expected *ScanError, got %v", tc.src, err)
+			continue
+		}
+		if scanErr.Target != "Version" {
+			t.Errorf("Scan(%v): expected target Version, got %s", tc.src, scanErr.Target)
+		}
+		if (scanErr.Err != nil) != tc.parseErr {
+			t.Errorf("Scan(%v): expected parse error %v, got %v", tc.src, tc.parseErr, scanErr.Err)
+		}
+	}
+}
+
+func TestNormalizedVersionScanValue(t *testing.T) {
+	for _, src := range []any{"v1.2", []byte("v1.2")} {
+		var v NormalizedVersion
+		if err := v.Scan(src); err != nil {
+			t.Fatalf("Scan(%v) unexpected error: %v", src, err)
+		}
+		if v.Version == nil || v.Original() != "v1.2" {
+			t.Fatalf("Scan(%v): expected version v1.2, got %v", src, v.Version)
+		}
+		value, err := v.Value()
+		if err != nil {
+			t.Fatalf("Value() unexpected error: %v", err)
+		}
+		if value != "1.2.0.0" {
+			t.Errorf("Value(): expected 1.2.0.0, got %v", value)
+		}
+	}
+
+	var empty NormalizedVersion
+	if value, err := empty.Value(); value != nil || err != nil {
+		t.Errorf("empty Value(): expected (nil, nil), got (%v, %v)", value, err)
+	}
+
+	v := NormalizedVersion{Version: Must(NewVersion("1.0"))}
+	if err := v.Scan(nil); err != nil || v.Version != nil {
+		t.Errorf("Scan(nil): expected an empty wrapper, got (%v, %v)", v.Version, err)
+	}
+
+	var scanErr *ScanError
+	if err := v.Scan("not a version"); !errors.As(err, &scanErr) || !errors.Is(err, ErrMalformedVersion) {
+		t.Errorf("Scan(not a version): expected *ScanError wrapping ErrMalformedVersion, got %v", err)
+	}
+	if err := v.Scan(42); !errors.As(err, &scanErr) || scanErr.Target != "Version" {
+		t.Errorf("Scan(42): expected *ScanError for Version, got %v", err)
+	}
+}
+
+func TestSemverScanValue(t *testing.T) {
+	v := Must(NewSemver("1.0.0-alpha.beta"))
+	value, err := v.Value()
+	if err != nil || value != "1.0.0-alpha.beta" {
+		t.Fatalf("Value(): expected 1.0.0-alpha.beta, got (%v, %v)", value, err)
+	}
+
+	cs := MustConstraints(NewConstraintWithOptions("^1.2.3", ParseOptions{Scheme: SemVer2}))
+	value, err = NullConstraints{Constraints: cs, Valid: true}.Value()
+	if err != nil || value != "^1.2.3" {
+		t.Fatalf("Value(): expected ^1.2.3, got (%v, %v)", value, err)
+	}
+}
+
+func TestConstraintsScanValue(t *testing.T) {
+	var cs Constraints
+	if err := cs.Scan([]byte("1.0 - 2.0 || ^3.0")); err != nil {
+		t.Fatalf("Scan unexpected error: %v", err)
+	}
+	if !cs.Check(Must(NewVersion("2.0.5"))) || cs.Check(Must(NewVersion("2.1.0"))) {
+		t.Errorf("scanned constraint does not match the hyphen range")
+	}
+
+	value, err := cs.Value()
+	if err != nil {
+		t.Fatalf("Value() unexpected error: %v", err)
+	}
+	var again Constraints
+	if err := again.Scan(value); err != nil {
+		t.Fatalf("Scan(%v) unexpected error: %v", value, err)
+	}
+	if !again.Check(Must(NewVersion("3.2.0"))) {
+		t.Errorf("constraint stored as %v no longer matches 3.2.0", value)
+	}
+
+	var scanErr *ScanError
+	if err := cs.Scan(">>1.0"); !errors.As(err, &scanErr) || scanErr.Target != "Constraints" {
+		t.Errorf("expected *ScanError for Constraints, got %v", err)
+	}
+}
+
+func TestNullVersion(t *testing.T) {
+	var n NullVersion
+	if err := n.Scan(nil); err != nil {
+		t.Fatalf("Scan(nil) unexpected error: %v", err)
+	}
+	if n.Valid || n.Version != nil {
+		t.Errorf("Scan(nil): expected invalid, got %+v", n)
+	}
+	if value, _ := n.Value(); value != nil {
+		t.Errorf("Value(): expected nil, got %v", value)
+	}
+
+	if err := n.Scan("1.0.0"); err != nil {
+		t.Fatalf("Scan unexpected error: %v", err)
+	}
+	if !n.Valid || n.Version.NormalizedString() != "1.0.0.0" {
+		t.Errorf("Scan(1.0.0): got %+v", n)
+	}
+	if value, _ := n.Value(); value != "1.0.0" {
+		t.Errorf("Value(): expected 1.0.0, got %v", value)
+	}
+
+	if err := n.Scan("not a version"); err == nil {
+		t.Error("expected error for malformed version")
+	}
+}
+
+func TestNullConstraints(t *testing.T) {
+	var n NullConstraints
+	if err := n.Scan(nil); err != nil {
+		t.Fatalf("Scan(nil) unexpected error: %v", err)
+	}
+	if n.Valid {
+		t.Errorf("Scan(nil): expected invalid, got %+v", n)
+	}
+	if value, _ := n.Value(); value != nil {
+		t.Errorf("Value(): expected nil, got %v", value)
+	}
+
+	if err := n.Scan("^1.2"); err != nil {
+		t.Fatalf("Scan unexpected error: %v", err)
+	}
+	if !n.Valid || !n.Constraints.Check(Must(NewVersion("1.5.0"))) {
+		t.Errorf("Scan(^1.2): got %+v", n)
+	}
+	if value, _ := n.Value(); value != "^1.2" {
+		t.Errorf("Value(): expected ^1.2, got %v", value)
+	}
+}
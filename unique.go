@@ -0,0 +1,107 @@
+package version
+
+import (
+	"slices"
+	"strings"
+)
+
+// This file holds Collection.Unique, which drops versions that differ only in
+// how they are written.
+
+// UniquePolicy picks which spelling of a version Unique keeps.
+type UniquePolicy int
+
+const (
+	// KeepFirst keeps the spelling seen first.
+	KeepFirst UniquePolicy = iota
+	// KeepPrefixed keeps the first spelling with a "v" prefix, such as
+	// v1.0.0, or the first one seen if none has it.
+	KeepPrefixed
+	// KeepMostSegments keeps the first spelling with the most numeric
+	// segments written, such as 1.0.0.0 over 1.0.0.
+	KeepMostSegments
+)
+
+// Collision is a group of versions in a collection that are the same
+// version, such as 1.0, 1.0.0, v1.0.0 and 1.0.0.0.
+type Collision struct {
+	// Kept is the version Unique kept.
+	Kept *Version
+	// Dropped holds the other versions of the group, in input order.
+	Dropped Collection
+}
+
+// Unique returns v without versions equal to another one, the ones for which
+// Compare returns 0, keeping one spelling of each according to policy. A
+// SemVer2 1.0.0 and a Composer 1.0.0 are equal, as are 1.0 and v1.0.0.0.
+// The kept versions stay in the order each version was first seen.
+//
+// Unique also reports every group of more than one version as a Collision,
+// in the same order.
+func (v Collection) Unique(policy UniquePolicy) (Collection, []Collision) {
+	// Sort a copy so that equal versions are adjacent; the stable sort keeps
+	// them in input order, so each group starts with its first version seen.
+	sorted := slices.Clone(v)
+	slices.SortStableFunc(sorted, func(a, b *Version) int {
+		return a.Compare(b)
+	})
+	var groups []Collection
+	for i, version := range sorted {
+		if i > 0 && version.Compare(sorted[i-1]) == 0 {
+			groups[len(groups)-1] = append(groups[len(groups)-1], version)
+			continue
+		}
+		groups = append(groups, Collection{version})
+	}
+	first := make(map[*Version]int, len(v))
+	for i, version := range v {
+		if _, ok := first[version]; !ok {
+			first[version] = i
+		}
+	}
+	slices.SortFunc(groups, func(a, b Collection) int {
+		return first[a[0]] - first[b[0]]
+	})
+
+	unique := make(Collection, 0, len(groups))
+	var collisions []Collision
+	for _, group := range groups {
+		kept := 0
+		for i, version := range group {
+			if policy.prefers(version, group[kept]) {
+				kept = i
+			}
+		}
+		unique = append(unique, group[kept])
+		if len(group) == 1 {
+			continue
+		}
+
+		collision := Collision{Kept: group[kept]}
+		for i, version := range group {
+			if i != kept {
+				collision.Dropped = append(collision.Dropped, version)
+			}
+		}
+		collisions = append(collisions, collision)
+	}
+	return unique, collisions
+}
+
+// prefers reports whether policy prefers candidate over kept, a version seen
+// before it.
+func (policy UniquePolicy) prefers(candidate, kept *Version) bool {
+	switch policy {
+	case KeepPrefixed:
+		return hasVersionPrefix(candidate) && !hasVersionPrefix(kept)
+	case KeepMostSegments:
+		return countVersionSegments(candidate.original) > countVersionSegments(kept.original)
+	default:
+		return false
+	}
+}
+
+func hasVersionPrefix(v *Version) bool {
+	original := strings.TrimSpace(v.original)
+	return strings.HasPrefix(original, "v") || strings.HasPrefix(original, "V")
+}
@@ -0,0 +1,105 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func describeCollisions(collisions []Collision) string {
+	described := make([]string, len(collisions))
+	for i, collision := range collisions {
+		described[i] = fmt.Sprintf("%s[%s]", collision.Kept.Original(), collectionOriginals(collision.Dropped))
+	}
+	return strings.Join(described, " ")
+}
+
+func TestCollectionUnique(t *testing.T) {
+	collection := newCollection(t, "1.0", "2.0.0", "1.0.0", "v1.0.0", "1.0.0.0", "1.0.0-beta1", "dev-main", "V2.0", "dev-main", "1.0.0+build.1")
+
+	tests := []struct {
+		name       string
+		policy     UniquePolicy
+		unique     string
+		collisions string
+	}{
+		{
+			"first", KeepFirst,
+			"1.0 2.0.0 1.0.0-beta1 dev-main",
+			"1.0[1.0.0 v1.0.0 1.0.0.0 1.0.0+build.1] 2.0.0[V2.0] dev-main[dev-main]",
+		},
+		{
+			"prefixed", KeepPrefixed,
+			"v1.0.0 V2.0 1.0.0-beta1 dev-main",
+			"v1.0.0[1.0 1.0.0 1.0.0.0 1.0.0+build.1] V2.0[2.0.0] dev-main[dev-main]",
+		},
+		{
+			"most segments", KeepMostSegments,
+			"1.0.0.0 2.0.0 1.0.0-beta1 dev-main",
+			"1.0.0.0[1.0 1.0.0 v1.0.0 1.0.0+build.1] 2.0.0[V2.0] dev-main[dev-main]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unique, collisions := collection.Unique(tt.policy)
+			if got := collectionOriginals(unique); got != tt.unique {
+				t.Errorf("Unique() = %q, expected %q", got, tt.unique)
+			}
+			if got := describeCollisions(collisions); got != tt.collisions {
+				t.Errorf("collisions = %q, expected %q", got, tt.collisions)
+			}
+		})
+	}
+}
+
+func TestCollectionUniqueMatchesCompare(t *testing.T) {
+	collection := newCollection(t, "1", "1.0", "1.0.0", "v1.0.0.0", "1.0-dev", "1.0.0-RC1", "1.0.0-rc1", "1.0-p1", "1.0.0-pl1", "1.0.1", "2010.01.02", "2010.1.2")
+	unique, _ := collection.Unique(KeepFirst)
+
+	for i, a := range unique {
+		for _, b := range unique[i+1:] {
+			if a.Compare(b) == 0 {
+				t.Errorf("Unique kept both %s and %s", a.Original(), b.Original())
+			}
+		}
+	}
+	for _, a := range collection {
+		found := false
+		for _, b := range unique {
+			found = found || a.Compare(b) == 0
+		}
+		if !found {
+			t.Errorf("Unique dropped %s without an equal version", a.Original())
+		}
+	}
+}
+
+func TestCollectionUniqueWithoutCollisions(t *testing.T) {
+	unique, collisions := newCollection(t, "1.0", "1.1", "2.0").Unique(KeepFirst)
+	if got := collectionOriginals(unique); got != "1.0 1.1 2.0" {
+		t.Errorf("Unique() = %q, expected %q", got, "1.0 1.1 2.0")
+	}
+	if collisions != nil {
+		t.Errorf("collisions = %s, expected none", describeCollisions(collisions))
+	}
+}
+
+func TestCollectionUniqueAcrossSchemes(t *testing.T) {
+	collection := Collection{
+		Must(NewSemver("1.0.0")),
+		Must(NewVersion("2.0")),
+		Must(NewVersion("1.0.0.0")),
+		Must(NewSemver("1.0.0-rc.1")),
+	}
+	unique, collisions := collection.Unique(KeepMostSegments)
+	if got := collectionOriginals(unique); got != "1.0.0.0 2.0 1.0.0-rc.1" {
+		t.Errorf("Unique() = %q, expected %q", got, "1.0.0.0 2.0 1.0.0-rc.1")
+	}
+	if got := describeCollisions(collisions); got != "1.0.0.0[1.0.0]" {
+		t.Errorf("collisions = %q, expected %q", got, "1.0.0.0[1.0.0]")
+	}
+	if collisions[0].Dropped[0].Scheme() != SemVer2 {
+		t.Errorf("expected the SemVer2 1.0.0 to be dropped")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -29,6 +30,14 @@ type Version struct {
 	si       int
 	original string
 	branch   string
+
+	// Annotations stripped during normalization; they never affect ordering.
+	metadata      string
+	stabilityFlag string
+	alias         *Version
+	aliasString   string
+
+	scheme Scheme
 }
 
 func init() {
@@ -42,24 +51,33 @@ func NewVersion(v string) (*Version, error) {
 }
 
 func newVersionFromRegExp(v string, pattern *regexp.Regexp) (*Version, error) {
-	normalized, err := normalizeVersion(v)
+	version, _, err := newAnnotatedVersion(v, pattern)
+	return version, err
+}
+
+// newAnnotatedVersion is newVersionFromRegExp that also reports what the
+// normalizer stripped from v.
+func newAnnotatedVersion(v string, pattern *regexp.Regexp) (*Version, versionAnnotations, error) {
+	normalized, annotations, err := normalizeAnnotatedVersion(v, v)
 
 	if err != nil {
-		return nil, err
+		return nil, annotations, err
 	}
 
 	if strings.HasPrefix(strings.ToLower(normalized), "dev-") {
-		return &Version{
+		version := &Version{
 			pre:      "dev",
 			segments: []int64{0, 0, 0},
 			original: v,
 			branch:   normalized,
-		}, nil
+		}
+		version.annotate(annotations)
+		return version, annotations, nil
 	}
 
 	matches := pattern.FindStringSubmatch(normalized)
 	if matches == nil {
-		return nil, fmt.Errorf("malformed version: %s", v)
+		return nil, annotations, newParseError(ErrMalformedVersion, v, strings.TrimSpace(v), "malformed version: "+v)
 	}
 	segmentsStr := strings.Split(matches[1], ".")
 	segments := make([]int64, len(segmentsStr))
@@ -67,8 +85,7 @@ func newVersionFromRegExp(v string, pattern *regexp.Regexp) (*Version, error) {
 	for i, str := range segmentsStr {
 		val, err := strconv.ParseInt(str, 10, 64)
 		if err != nil {
-			return nil, fmt.Errorf(
-				"error parsing version: %s", err)
+			return nil, annotations, newParseError(ErrMalformedVersion, v, str, fmt.Sprintf("error parsing version: %s", err))
 		}
 
 		segments[i] = val
@@ -87,12 +104,37 @@ func newVersionFromRegExp(v string, pattern *regexp.Regexp) (*Version, error) {
 		pre = matches[4]
 	}
 
-	return &Version{
+	version := &Version{
 		pre:      pre,
 		segments: segments,
 		si:       si,
 		original: v,
-	}, nil
+	}
+	version.annotate(annotations)
+	return version, annotations, nil
+}
+
+// annotate records what the normalizer stripped from the original string. An
+// alias that does not parse as a version is kept only as text, matching
+// Composer, which only validates aliases where they are used.
+func (v *Version) annotate(annotations versionAnnotations) {
+	v.metadata = annotations.metadata
+	if annotations.stability != "" {
+		v.stabilityFlag = expandStability(annotations.stability)
+	}
+	v.aliasString = annotations.alias
+	if annotations.alias != "" {
+		if alias, err := NewVersion(annotations.alias); err == nil {
+			v.alias = alias
+		}
+	}
+}
+
+// clone returns a copy of v that does not share its segments.
+func (v *Version) clone() *Version {
+	clone := *v
+	clone.segments = slices.Clone(v.segments)
+	return &clone
 }
 
 // Must is a helper that wraps a call to a function returning (*Version, error)
@@ -132,6 +174,10 @@ func (v *Version) Compare(other *Version) int {
 		return 1
 	}
 
+	if v.scheme == SemVer2 || other.scheme == SemVer2 {
+		return compareSemver(v, other)
+	}
+
 	// Read segments directly; we only compare them here, so the defensive copy
 	// from Segments64() is unnecessary.
 	segmentsSelf := v.segments
@@ -505,6 +551,35 @@ func (v *Version) Prerelease() string {
 	return v.pre
 }
 
+// Metadata returns the build metadata that followed "+" in the original
+// version string, or blank if there was none. For "1.2.3+build.5" it returns
+// "build.5". Metadata does not affect comparison.
+func (v *Version) Metadata() string {
+	return v.metadata
+}
+
+// StabilityFlag returns the "@" stability flag of the original version
+// string, such as "beta" for "1.2.3@beta", or blank if there was none. The
+// flag is reported as one of the Stability constants and does not affect
+// comparison.
+func (v *Version) StabilityFlag() string {
+	return v.stabilityFlag
+}
+
+// Alias returns the version after " as " in the original string, such as
+// 1.0.x-dev for "dev-main as 1.0.x-dev", or nil if there was no alias or it
+// did not parse. The alias does not affect comparison.
+func (v *Version) Alias() *Version {
+	return v.alias
+}
+
+// AliasString returns the text after " as " in the original string as
+// written, or blank if there was none. Unlike Alias it is kept when the alias
+// does not parse, as in "1.2.3 as 1.2.3-alias".
+func (v *Version) AliasString() string {
+	return v.aliasString
+}
+
 // IsPrerelease returns true if the version has prerelease information.
 func (v *Version) IsPrerelease() bool {
 	return v.pre != ""
@@ -590,6 +665,14 @@ func (v *Version) NormalizedString() string {
 		return v.branch
 	}
 
+	if v.scheme == SemVer2 {
+		normalized := fmt.Sprintf("%d.%d.%d", v.segments[0], v.segments[1], v.segments[2])
+		if v.pre != "" {
+			normalized += "-" + v.pre
+		}
+		return normalized
+	}
+
 	var buf bytes.Buffer
 	segments := v.segments
 	if v.si > 0 && v.si <= len(v.segments) {
@@ -1,5 +1,7 @@
 package version
 
+import "slices"
+
 // Collection is a type that implements the sort.Interface interface
 // so that versions can be sorted.
 type Collection []*Version
@@ -16,6 +18,86 @@ func (v Collection) Swap(i, j int) {
 	v[i], v[j] = v[j], v[i]
 }
 
+// Filter returns the versions in v that satisfy cs, in their original order,
+// like Composer's Semver::satisfiedBy.
+func (v Collection) Filter(cs Constraints) Collection {
+	var filtered Collection
+	for _, version := range v {
+		if cs.Check(version) {
+			filtered = append(filtered, version)
+		}
+	}
+	return filtered
+}
+
+// Stable returns the versions in v with stable stability, in their original
+// order. Patch releases such as 1.0-p1 count as stable; other prereleases and
+// branches are dropped.
+func (v Collection) Stable() Collection {
+	var stable Collection
+	for _, version := range v {
+		if getVersionStability(version) == "stable" {
+			stable = append(stable, version)
+		}
+	}
+	return stable
+}
+
+// Sorted returns a copy of v sorted from lowest to highest, like Composer's
+// Semver::sort. Equal versions keep their original order.
+func (v Collection) Sorted() Collection {
+	sorted := slices.Clone(v)
+	slices.SortStableFunc(sorted, compareForSort)
+	return sorted
+}
+
+// RSorted returns a copy of v sorted from highest to lowest, like Composer's
+// Semver::rsort. Equal versions keep their original order.
+func (v Collection) RSorted() Collection {
+	sorted := slices.Clone(v)
+	slices.SortStableFunc(sorted, func(left, right *Version) int {
+		return compareForSort(right, left)
+	})
+	return sorted
+}
+
+// Latest returns the highest version in v in sort order, so dev-master,
+// dev-trunk and dev-default rank above every release. It returns nil if v is
+// empty; of equal versions the first one wins.
+func (v Collection) Latest() *Version {
+	var latest *Version
+	for _, version := range v {
+		if latest == nil || compareForSort(version, latest) > 0 {
+			latest = version
+		}
+	}
+	return latest
+}
+
+// Oldest returns the lowest version in v in sort order, or nil if v is empty.
+// Of equal versions the first one wins.
+func (v Collection) Oldest() *Version {
+	var oldest *Version
+	for _, version := range v {
+		if oldest == nil || compareForSort(version, oldest) < 0 {
+			oldest = version
+		}
+	}
+	return oldest
+}
+
+// MaxSatisfying returns the highest version in v that satisfies cs, or nil
+// if none does.
+func (v Collection) MaxSatisfying(cs Constraints) *Version {
+	return v.Filter(cs).Latest()
+}
+
+// MinSatisfying returns the lowest version in v that satisfies cs, or nil if
+// none does.
+func (v Collection) MinSatisfying(cs Constraints) *Version {
+	return v.Filter(cs).Oldest()
+}
+
 func compareForSort(left, right *Version) int {
 	return sortVersion(left).Compare(sortVersion(right))
 }
@@ -0,0 +1,134 @@
+package version
+
+import (
+	"strings"
+	"testing"
+)
+
+func newCollection(t *testing.T, versions ...string) Collection {
+	t.Helper()
+	collection := make(Collection, len(versions))
+	for i, raw := range versions {
+		v, err := NewVersion(raw)
+		if err != nil {
+			t.Fatalf("NewVersion(%q): %v", raw, err)
+		}
+		collection[i] = v
+	}
+	return collection
+}
+
+func collectionOriginals(collection Collection) string {
+	originals := make([]string, len(collection))
+	for i, v := range collection {
+		originals[i] = v.Original()
+	}
+	return strings.Join(originals, " ")
+}
+
+func versionOriginal(v *Version) string {
+	if v == nil {
+		return "<nil>"
+	}
+	return v.Original()
+}
+
+func TestCollectionFilter(t *testing.T) {
+	collection := newCollection(t, "1.0", "1.2.3", "1.5.0-beta1", "2.0.0", "dev-master", "1.x-dev")
+
+	tests := []struct {
+		constraint string
+		expected   string
+	}{
+		{"^1.2", "1.2.3 1.5.0-beta1 1.x-dev"},
+		{"^1.2, !=1.2.3", "1.5.0-beta1 1.x-dev"},
+		{">=2.0 || dev-master", "2.0.0 dev-master"},
+		{"^3.0", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			cs := MustConstraints(NewConstraint(tt.constraint))
+			if got := collectionOriginals(collection.Filter(cs)); got != tt.expected {
+				t.Errorf("Filter(%q) = %q, expected %q", tt.constraint, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCollectionStable(t *testing.T) {
+	collection := newCollection(t, "1.0-dev", "1.0-alpha1", "1.0-beta2", "1.0-RC1", "1.0", "1.0-p1", "dev-master", "1.x-dev")
+	if got := collectionOriginals(collection.Stable()); got != "1.0 1.0-p1" {
+		t.Errorf("Stable() = %q, expected %q", got, "1.0 1.0-p1")
+	}
+}
+
+func TestCollectionSorted(t *testing.T) {
+	collection := newCollection(t, "dev-master", "1.10.0", "1.0", "dev-foo", "1.0.0", "1.2.0-RC1", "dev-trunk", "1.2.0")
+
+	if got, expected := collectionOriginals(collection.Sorted()), "dev-foo 1.0 1.0.0 1.2.0-RC1 1.2.0 1.10.0 dev-master dev-trunk"; got != expected {
+		t.Errorf("Sorted() = %q, expected %q", got, expected)
+	}
+	if got, expected := collectionOriginals(collection.RSorted()), "dev-master dev-trunk 1.10.0 1.2.0 1.2.0-RC1 1.0 1.0.0 dev-foo"; got != expected {
+		t.Errorf("RSorted() = %q, expected %q", got, expected)
+	}
+	if got, expected := collectionOriginals(collection), "dev-master 1.10.0 1.0 dev-foo 1.0.0 1.2.0-RC1 dev-trunk 1.2.0"; got != expected {
+		t.Errorf("receiver changed to %q", got)
+	}
+}
+
+func TestCollectionLatestAndOldest(t *testing.T) {
+	tests := []struct {
+		versions []string
+		latest   string
+		oldest   string
+	}{
+		{nil, "<nil>", "<nil>"},
+		{[]string{"1.0", "2.0-beta1", "1.5"}, "2.0-beta1", "1.0"},
+		{[]string{"1.0", "dev-master", "9.0"}, "dev-master", "1.0"},
+		{[]string{"dev-default", "dev-trunk"}, "dev-default", "dev-default"},
+		{[]string{"1.0", "dev-foo"}, "1.0", "dev-foo"},
+		{[]string{"1.0", "1.0.0", "v1.0"}, "1.0", "1.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.versions, ","), func(t *testing.T) {
+			collection := newCollection(t, tt.versions...)
+			if got := versionOriginal(collection.Latest()); got != tt.latest {
+				t.Errorf("Latest() = %s, expected %s", got, tt.latest)
+			}
+			if got := versionOriginal(collection.Oldest()); got != tt.oldest {
+				t.Errorf("Oldest() = %s, expected %s", got, tt.oldest)
+			}
+		})
+	}
+}
+
+func TestCollectionSatisfying(t *testing.T) {
+	collection := newCollection(t, "1.0.0", "1.2.0", "1.4.5", "2.0.0-RC1", "2.0.0", "2.1.0", "dev-master")
+
+	tests := []struct {
+		constraint string
+		max        string
+		min        string
+	}{
+		{"^1.0", "1.4.5", "1.0.0"},
+		{"^2.0", "2.1.0", "2.0.0-RC1"},
+		{">=1.2 <2.0 || dev-master", "dev-master", "1.2.0"},
+		{"*", "dev-master", "1.0.0"},
+		{"*@stable", "2.1.0", "1.0.0"},
+		{"^3.0", "<nil>", "<nil>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			cs := MustConstraints(NewConstraint(tt.constraint))
+			if got := versionOriginal(collection.MaxSatisfying(cs)); got != tt.max {
+				t.Errorf("MaxSatisfying(%q) = %s, expected %s", tt.constraint, got, tt.max)
+			}
+			if got := versionOriginal(collection.MinSatisfying(cs)); got != tt.min {
+				t.Errorf("MinSatisfying(%q) = %s, expected %s", tt.constraint, got, tt.min)
+			}
+		})
+	}
+}
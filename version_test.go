@@ -469,7 +469,7 @@ func TestConstraintPrerelease(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		c, err := parseSingle(tc.constraint)
+		c, err := parseSingle(tc.constraint, Composer)
 		if err != nil {
 			t.Fatalf("err: %s", err)
 		}
@@ -790,3 +790,76 @@ func TestMajorMinorMethods(t *testing.T) {
 		t.Errorf("Expected Patch() to return 3, got %d", v.Patch())
 	}
 }
+
+func TestVersionAnnotations(t *testing.T) {
+	tests := []struct {
+		input      string
+		normalized string
+		metadata   string
+		stability  string
+		alias      string
+	}{
+		{"1.2.3", "1.2.3.0", "", "", ""},
+		{"1.2.3+build.5", "1.2.3.0", "build.5", "", ""},
+		{"1.2.3@beta", "1.2.3.0", "", StabilityBeta, ""},
+		{"1.0.0-rc1@RC", "1.0.0.0-RC1", "", StabilityRC, ""},
+		{"dev-main as 1.0.x-dev", "dev-main", "", "", "1.0.9999999.9999999-dev"},
+		{"dev-main@dev as 2.1.0", "dev-main", "", StabilityDev, "2.1.0.0"},
+		{"1.0.0+abc@stable as 1.0.1", "1.0.0.0", "abc", StabilityStable, "1.0.1.0"},
+	}
+
+	for _, tc := range tests {
+		v := Must(NewVersion(tc.input))
+		if v.NormalizedString() != tc.normalized {
+			t.Errorf("%q: expected normalized %s, got %s", tc.input, tc.normalized, v.NormalizedString())
+		}
+		if v.Metadata() != tc.metadata {
+			t.Errorf("%q: expected metadata %q, got %q", tc.input, tc.metadata, v.Metadata())
+		}
+		if v.StabilityFlag() != tc.stability {
+			t.Errorf("%q: expected stability flag %q, got %q", tc.input, tc.stability, v.StabilityFlag())
+		}
+		alias := ""
+		if v.Alias() != nil {
+			alias = v.Alias().NormalizedString()
+		}
+		if alias != tc.alias {
+			t.Errorf("%q: expected alias %q, got %q", tc.input, tc.alias, alias)
+		}
+	}
+}
+
+func TestVersionAnnotationsDoNotAffectComparison(t *testing.T) {
+	tests := [][2]string{
+		{"1.2.3+build.5", "1.2.3+build.6"},
+		{"1.2.3@beta", "1.2.3"},
+		{"1.2.3 as 2.0.0", "1.2.3"},
+	}
+
+	for _, tc := range tests {
+		left := Must(NewVersion(tc[0]))
+		right := Must(NewVersion(tc[1]))
+		if left.Compare(right) != 0 || !left.Equal(right) {
+			t.Errorf("expected %q to equal %q", tc[0], tc[1])
+		}
+	}
+
+	for input, expected := range map[string]string{
+		"dev-main as foo~bar":  "foo~bar",
+		"1.2.3 as 1.2.3-alias": "1.2.3-alias",
+	} {
+		v := Must(NewVersion(input))
+		if alias := v.Alias(); alias != nil {
+			t.Errorf("%q: expected nil alias for unparsable alias, got %s", input, alias)
+		}
+		if v.AliasString() != expected {
+			t.Errorf("%q: expected alias string %q, got %q", input, expected, v.AliasString())
+		}
+	}
+	if alias := Must(NewVersion("dev-main as 1.0.x-dev")).AliasString(); alias != "1.0.x-dev" {
+		t.Errorf("expected alias string 1.0.x-dev, got %q", alias)
+	}
+	if alias := Must(NewVersion("1.2.3")).AliasString(); alias != "" {
+		t.Errorf("expected no alias string, got %q", alias)
+	}
+}